@@ -6,35 +6,48 @@ implementation if it does not.
 The application will start by initializing every given components, linking them to
 each other with the Inventory.
 
-The components which implements the optional Validator interface will then be validated,
-any returned error will abort the application.
+The components will then be validated against the rules of their validate tags
+and through the optional Validator interface, together with the configuration sections
+given to WithConfigFile, any violation will abort the application.
 
 If no error has been returned, the application will then move to the next phase.
 Every components which implements the option StartHook interface will be started
-in the same order than it was given in the call to WithComponents.
+after the components it depends on, as ordered by the dependency graph of the Inventory.
+The independent components are started in the same order than they were given to WithComponent.
+The lazy components which are not needed by any other component are never initialized
+nor started, see WithLazyComponent.
 Returning an error from the Start callback will abort the application.
 
 Finally, the application will run until it receives an interruption signal, or its context
 has been canceled or expired, or an error has been returned from the Start callback,
 
+While running, the configuration given to WithConfigFile is reloaded when the application
+receives the SIGHUP signal, or when one of the files changes if WithConfigWatch has been used.
+The components implementing the scaffolder.Reconfigurable interface are then reconfigured.
+
 Once the application initiate its interruption, the components implementing
 the StopHook interface will be asked to stop and forcefully stopped if they do not perform
 after the configured grace period.
-The components will be stopped in the reverse order than the one given in the call
-to WithComponents.
+The components will be stopped in the reverse order than they were started.
 */
 package application
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"log"
 	"os"
 	"os/signal"
+	"reflect"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/Vorian-Atreides/scaffolder"
+	"github.com/Vorian-Atreides/scaffolder/config"
 )
 
 // Application should describe your application.
@@ -43,16 +56,36 @@ type Application struct {
 	version        string
 	gracefulPeriod time.Duration
 
+	ctx        context.Context
 	inventory  *scaffolder.Inventory
-	components []scaffolder.Component
+	configOpts []scaffolder.Option
+	flags      *flag.FlagSet
+	args       []string
+
+	loader         *config.Loader
+	reloadMutex    sync.Mutex
+	watchInterval  time.Duration
+	onReloadFailed func(error)
+
+	// modTimes are the modification times of the configuration files before their first reading.
+	modTimes []time.Time
+
+	dumpWriter io.Writer
+	dumpFormat string
 }
 
-// Default assign the default variables for the application component.
-func (a *Application) Default() {
+// Default assign the default variables for the application component,
+// the context is kept to initialize the components given to WithComponent.
+func (a *Application) Default(ctx context.Context) error {
 	a.name = os.Args[0]
 	a.version = "0.0.0"
 	a.gracefulPeriod = time.Second
+	a.ctx = ctx
 	a.inventory = scaffolder.New()
+	a.onReloadFailed = func(err error) {
+		log.Printf("unable to reload the configuration: %v", err)
+	}
+	return nil
 }
 
 // String implements the Stringer interface.
@@ -62,8 +95,14 @@ func (a *Application) String() string {
 
 // New build an application and customize it with the given Options.
 func New(opts ...scaffolder.Option) (*Application, error) {
+	return NewContext(context.Background(), opts...)
+}
+
+// NewContext behave like New, the context is given to the components
+// implementing the scaffolder.ContextDefaulter interface.
+func NewContext(ctx context.Context, opts ...scaffolder.Option) (*Application, error) {
 	app := &Application{}
-	return app, scaffolder.Init(app, opts...)
+	return app, scaffolder.InitContext(ctx, app, opts...)
 }
 
 // WithGracefulPeriod set the grace period allocated for stopping a component.
@@ -78,8 +117,113 @@ func WithGracefulPeriod(duration time.Duration) scaffolder.Option {
 // WithComponent is used to attach register a component in the application life cycle.
 func WithComponent(component scaffolder.Component, opts ...scaffolder.Option) scaffolder.Option {
 	return func(a *Application) error {
-		a.components = append(a.components, component)
-		a.inventory.Add(component, opts...)
+		a.inventory.AddContext(a.ctx, component, opts...)
+		return nil
+	}
+}
+
+// WithLazyComponent register a component which is only initialized and part of the application
+// life cycle if another component depends on it, see scaffolder.Inventory.AddLazy.
+func WithLazyComponent(component scaffolder.Component, opts ...scaffolder.Option) scaffolder.Option {
+	return func(a *Application) error {
+		a.inventory.AddLazyContext(a.ctx, component, opts...)
+		return nil
+	}
+}
+
+// WithProvider register the constructor of a component in the application life cycle,
+// see scaffolder.Inventory.Provide.
+func WithProvider(constructor interface{}, opts ...scaffolder.Option) scaffolder.Option {
+	return func(a *Application) error {
+		a.inventory.Provide(constructor, opts...)
+		return nil
+	}
+}
+
+// WithLazyProvider register the constructor of a component which is only called if another
+// component depends on it, see scaffolder.Inventory.ProvideLazy.
+func WithLazyProvider(constructor interface{}, opts ...scaffolder.Option) scaffolder.Option {
+	return func(a *Application) error {
+		a.inventory.ProvideLazy(constructor, opts...)
+		return nil
+	}
+}
+
+// WithOverride replace a component given to the application, such as a database client
+// swapped for a fake in the integration tests, see scaffolder.Inventory.Replace.
+// The override can be given before or after the component it replaces.
+//
+// The override is matched by its container name, and otherwise by its exact type:
+// a fake of a different type must be given the name of the component it replaces.
+//
+//   app, err := application.New(append(opts,
+//   	application.WithOverride(&FakeDB{}, scaffolder.WithName("db")),
+//   )...)
+func WithOverride(component scaffolder.Component, opts ...scaffolder.Option) scaffolder.Option {
+	return func(a *Application) error {
+		a.inventory.ReplaceContext(a.ctx, component, opts...)
+		return nil
+	}
+}
+
+// WithConfigFile read the configuration file and configure every component implementing
+// the scaffolder.Configurable interface with the top-level section named after its container.
+// The options are given to the config.Loader, such as config.WithEnvironment.
+//
+//   readiness:
+//     interval: 1s
+//   logger:
+//     level: info
+func WithConfigFile(path string, opts ...scaffolder.Option) scaffolder.Option {
+	return func(a *Application) error {
+		a.configOpts = append(append(a.configOpts, config.WithFile(path)), opts...)
+		return nil
+	}
+}
+
+// WithFlags register in the FlagSet the flags of every component implementing the
+// scaffolder.Configurable interface, namespaced by its container name: -readiness.interval.
+// The arguments are parsed before running the application and the flags override
+// the values of the configuration files.
+//
+//   application.WithFlags(flag.CommandLine, os.Args[1:])
+func WithFlags(fs *flag.FlagSet, args []string) scaffolder.Option {
+	return func(a *Application) error {
+		a.flags = fs
+		a.args = args
+		return nil
+	}
+}
+
+// WithConfigDump turn the application into a command which write the effective configuration
+// of its components in the given format, json, yaml or toml, instead of starting them.
+//
+//   if *dumpConfig {
+//   	opts = append(opts, application.WithConfigDump(os.Stdout, "yaml"))
+//   }
+func WithConfigDump(w io.Writer, format string) scaffolder.Option {
+	return func(a *Application) error {
+		a.dumpWriter = w
+		a.dumpFormat = format
+		return nil
+	}
+}
+
+// WithConfigWatch check at the given interval if one of the configuration files given
+// to WithConfigFile, or one of their environment specific variants, has been modified
+// and reload the configuration if it did.
+func WithConfigWatch(interval time.Duration) scaffolder.Option {
+	return func(a *Application) error {
+		a.watchInterval = interval
+		return nil
+	}
+}
+
+// WithReloadErrorHandler set the function called when the configuration could not
+// be reloaded, the default handler log the error with the standard logger.
+func WithReloadErrorHandler(handler func(error)) scaffolder.Option {
+	return func(a *Application) error {
+		a.onReloadFailed = handler
 		return nil
 	}
 }
@@ -100,18 +244,125 @@ func WithName(name string) scaffolder.Option {
 	}
 }
 
-func (a *Application) validate() error {
-	for _, component := range a.components {
-		// Validate the components before starting them.
-		if validator, ok := component.(Validator); ok {
-			if err := validator.Validate(); err != nil {
+func (a *Application) bindFlags() error {
+	for _, container := range a.inventory.Containers() {
+		if configurable, ok := container.Component().(scaffolder.Configurable); ok {
+			err := scaffolder.BindFlags(a.flags, container.Name(), configurable.Configuration())
+			if err != nil {
 				return err
 			}
 		}
 	}
+	if err := a.flags.Parse(a.args); err != nil {
+		return err
+	}
+	// The flags are given to the loader, so they keep precedence over
+	// the configuration files when reloading them.
+	a.configOpts = append(a.configOpts, config.WithFlags(a.flags))
 	return nil
 }
 
+func (a *Application) configure() error {
+	if a.flags != nil {
+		if err := a.bindFlags(); err != nil {
+			return err
+		}
+	}
+	if len(a.configOpts) == 0 {
+		return nil
+	}
+
+	loader, err := config.New(a.configOpts...)
+	if err != nil {
+		return err
+	}
+	a.loader = loader
+	// The files modified while being read for the first time are reloaded.
+	a.modTimes = modTimes(loader.Files())
+	return a.inventory.Configure(loader)
+}
+
+// Dump write the effective configuration of every component in the given format:
+// json, yaml or toml. The secrets are masked, see scaffolder.Inventory.Snapshot.
+func (a *Application) Dump(w io.Writer, format string) error {
+	return config.Encode(w, format, a.inventory.Snapshot())
+}
+
+// Reload read the configuration files again and reconfigure the components
+// implementing the scaffolder.Reconfigurable interface whose configuration changed.
+func (a *Application) Reload(ctx context.Context) error {
+	a.reloadMutex.Lock()
+	defer a.reloadMutex.Unlock()
+
+	if a.loader == nil {
+		return nil
+	}
+	if err := a.loader.Read(); err != nil {
+		return err
+	}
+	return a.inventory.Reconfigure(ctx, a.loader)
+}
+
+// watch notify the reload channel every time one of the configuration files,
+// or one of their environment specific variants, is modified, created or removed.
+func (a *Application) watch(ctx context.Context, reloadC chan<- os.Signal) {
+	files := a.loader.Files()
+	if a.watchInterval <= 0 || len(files) == 0 {
+		return
+	}
+
+	previous := a.modTimes
+	ticker := time.NewTicker(a.watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := modTimes(files)
+		if reflect.DeepEqual(current, previous) {
+			continue
+		}
+		previous = current
+		select {
+		case reloadC <- syscall.SIGHUP:
+		default:
+		}
+	}
+}
+
+// modTimes return the modification time of the files, the missing files are left zero.
+func modTimes(files []string) []time.Time {
+	times := make([]time.Time, len(files))
+	for idx, path := range files {
+		if info, err := os.Stat(path); err == nil {
+			times[idx] = info.ModTime()
+		}
+	}
+	return times
+}
+
+// validate check the validate tags of the components and calls the Validator interface,
+// every violation is aggregated into a scaffolder.Errors with the ones of the configuration.
+func (a *Application) validate(violations scaffolder.Errors) error {
+	errs := append(scaffolder.Errors{}, violations...)
+	if err := a.inventory.Validate(); err != nil {
+		errs = append(errs, err.(scaffolder.Errors)...)
+	}
+
+	for _, container := range a.inventory.Containers() {
+		// Validate the components before starting them.
+		if validator, ok := container.Component().(Validator); ok {
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", container.Name(), err))
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
 func (a *Application) stopWithTimeout(ctx context.Context, s StopHook) func() error {
 	return func() error {
 		ctx, cancel := context.WithTimeout(ctx, a.gracefulPeriod)
@@ -123,13 +374,25 @@ func (a *Application) stopWithTimeout(ctx context.Context, s StopHook) func() er
 // Run the application until it receives an interruption signal, or until its context
 // has been canceled or expired, or until an error has been returned from the Start callback.
 //
-// The application would return an error if it was unable to Add a component, links the components,
-// validate the components, start the components or stop the components.
+// The application would return an error if it was unable to Add a component, configure the components,
+// links the components, validate the components, start the components or stop the components.
 func (a *Application) Run(ctx context.Context) (err error) {
+	// The violations of the configuration are reported with the ones of the components.
+	var violations scaffolder.Errors
+	if err := a.configure(); err != nil && !errors.As(err, &violations) {
+		return err
+	}
 	if err := a.inventory.Compile(); err != nil {
 		return err
 	}
-	if err := a.validate(); err != nil {
+	containers, err := a.inventory.Graph().Order()
+	if err != nil {
+		return err
+	}
+	if a.dumpWriter != nil {
+		return a.Dump(a.dumpWriter, a.dumpFormat)
+	}
+	if err := a.validate(violations); err != nil {
 		return err
 	}
 
@@ -142,8 +405,16 @@ func (a *Application) Run(ctx context.Context) (err error) {
 	childCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	reloadC := make(chan os.Signal, 1)
+	if a.loader != nil {
+		signal.Notify(reloadC, syscall.SIGHUP)
+		defer signal.Stop(reloadC)
+		go a.watch(childCtx, reloadC)
+	}
+
 	runtimeErr := make(chan error)
-	for _, component := range a.components {
+	for _, container := range containers {
+		component := container.Component()
 		// Start the component in its own Goroutine and
 		// block until the scheduler started it.
 		if s, ok := component.(StartHook); ok {
@@ -184,6 +455,10 @@ func (a *Application) Run(ctx context.Context) (err error) {
 			return
 		case <-signalC:
 			return
+		case <-reloadC:
+			if err := a.Reload(childCtx); err != nil {
+				a.onReloadFailed(err)
+			}
 		case err = <-runtimeErr:
 			if err != nil {
 				return err
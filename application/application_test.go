@@ -0,0 +1,247 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Vorian-Atreides/scaffolder"
+	"github.com/Vorian-Atreides/scaffolder/config"
+)
+
+type server struct {
+	Port int
+}
+
+func (s *server) Configuration() scaffolder.Configuration {
+	return &serverConfig{Port: s.Port}
+}
+
+type serverConfig struct {
+	Port int `flag:"port" json:"port"`
+}
+
+func (c *serverConfig) Options() []scaffolder.Option {
+	return []scaffolder.Option{func(s *server) error {
+		s.Port = c.Port
+		return nil
+	}}
+}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// run the application until its components have been configured and linked.
+func run(t *testing.T, opts ...scaffolder.Option) error {
+	t.Helper()
+	app, err := New(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return app.Run(ctx)
+}
+
+func TestWithFlags(t *testing.T) {
+	public, admin := &server{}, &server{}
+	err := run(t,
+		WithComponent(public, scaffolder.WithName("public")),
+		WithComponent(admin, scaffolder.WithName("admin")),
+		WithFlags(newFlagSet(), []string{"-public.port=80", "-admin.port=9090"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if public.Port != 80 || admin.Port != 9090 {
+		t.Errorf("the flags should be namespaced by the container names: %d, %d", public.Port, admin.Port)
+	}
+}
+
+func TestWithFlagsDuplicate(t *testing.T) {
+	defined := newFlagSet()
+	defined.Int("public.port", 0, "")
+
+	tests := []struct {
+		name string
+		opts []scaffolder.Option
+	}{
+		{
+			name: "two components share the same name",
+			opts: []scaffolder.Option{
+				WithComponent(&server{}, scaffolder.WithName("public")),
+				WithComponent(&server{}, scaffolder.WithName("public")),
+				WithFlags(newFlagSet(), nil),
+			},
+		},
+		{
+			name: "the flag is already defined",
+			opts: []scaffolder.Option{
+				WithComponent(&server{}, scaffolder.WithName("public")),
+				WithFlags(defined, nil),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := run(t, test.opts...); !errors.Is(err, scaffolder.ErrDuplicateFlag) {
+				t.Errorf("expected %v, got: %v", scaffolder.ErrDuplicateFlag, err)
+			}
+		})
+	}
+}
+
+func TestWithComponentOptionT(t *testing.T) {
+	public, admin := &server{}, &server{}
+	err := run(t,
+		WithComponent(public, scaffolder.WithName("public"), scaffolder.OptionT[server](func(s *server) error {
+			s.Port = 80
+			return nil
+		})),
+		WithComponent(admin, scaffolder.WithName("admin"), func(s *server) error {
+			s.Port = 9090
+			return nil
+		}),
+		WithFlags(newFlagSet(), []string{"-admin.port=9091"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if public.Port != 80 || admin.Port != 9091 {
+		t.Errorf("the OptionT should be applied next to the reflective options: %d, %d", public.Port, admin.Port)
+	}
+}
+
+type regionKey struct{}
+
+var errNoRegion = errors.New("no region")
+
+// regionalServer read its region from the context given to NewContext.
+type regionalServer struct {
+	Region string
+}
+
+func (s *regionalServer) Default(ctx context.Context) error {
+	region, ok := ctx.Value(regionKey{}).(string)
+	if !ok {
+		return errNoRegion
+	}
+	s.Region = region
+	return nil
+}
+
+func TestNewContextDefaulter(t *testing.T) {
+	ctx := context.WithValue(context.Background(), regionKey{}, "eu")
+	regional := &regionalServer{}
+	app, err := NewContext(ctx, WithComponent(regional))
+	if err != nil {
+		t.Fatal(err)
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := app.Run(canceled); err != nil {
+		t.Fatal(err)
+	}
+	if regional.Region != "eu" {
+		t.Errorf("the context given to NewContext should be given to the defaulter: %q", regional.Region)
+	}
+
+	err = run(t, WithComponent(&regionalServer{}, scaffolder.WithName("regional")))
+	var initErr *scaffolder.InitError
+	if !errors.As(err, &initErr) || !errors.Is(err, errNoRegion) || initErr.Container != "regional" {
+		t.Errorf("expected an InitError wrapping %v, got: %v", errNoRegion, err)
+	}
+}
+
+// reloadedServer report when it is started and the ports it is reconfigured with.
+type reloadedServer struct {
+	server
+	started chan struct{}
+	ports   chan int
+}
+
+func (s *reloadedServer) Start(ctx context.Context) error {
+	close(s.started)
+	<-ctx.Done()
+	return nil
+}
+
+func (s *reloadedServer) Configuration() scaffolder.Configuration {
+	return &reloadedServerConfig{Port: s.Port}
+}
+
+type reloadedServerConfig serverConfig
+
+func (c *reloadedServerConfig) Options() []scaffolder.Option {
+	return []scaffolder.Option{func(s *reloadedServer) error {
+		s.Port = c.Port
+		return nil
+	}}
+}
+
+func (s *reloadedServer) Reconfigure(ctx context.Context, opts []scaffolder.Option) error {
+	cp := reloadedServer{server: s.server}
+	if err := scaffolder.Apply(&cp, opts...); err != nil {
+		return err
+	}
+	s.Port = cp.Port
+	s.ports <- cp.Port
+	return nil
+}
+
+func TestWithConfigWatch(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	variant := filepath.Join(dir, "config.production.yaml")
+	if err := os.WriteFile(base, []byte("server:\n  port: 80\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := &reloadedServer{started: make(chan struct{}), ports: make(chan int, 1)}
+	app, err := New(
+		WithComponent(server, scaffolder.WithName("server")),
+		WithConfigFile(base, config.WithEnvironment("production")),
+		WithConfigWatch(10*time.Millisecond),
+		WithReloadErrorHandler(func(err error) { t.Errorf("unable to reload the configuration: %v", err) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(ctx)
+	}()
+
+	select {
+	case <-server.started:
+	case err := <-done:
+		t.Fatalf("the application stopped: %v", err)
+	}
+
+	// The environment specific variant is created once the application is running.
+	if err := os.WriteFile(variant, []byte("server:\n  port: 8080\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case port := <-server.ports:
+		if port != 8080 {
+			t.Errorf("the server should be reconfigured from the variant: %d", port)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("the environment specific variant has not been watched")
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,49 @@
+package scaffolder
+
+// Child create an Inventory inheriting every container of its parent, it is intended
+// to build a specialized wiring, such as per tenant or per test, over a shared base inventory.
+//
+//   base := scaffolder.New().Add(logger.New()).Add(&Registry{})
+//   if err := base.Compile(); err != nil {
+//   	return err
+//   }
+//   tenant := base.Child().Add(&Handler{}).Add(&FakeDB{}, scaffolder.WithName("DB"))
+//
+// The fields of the child components are resolved with the same priority, the local
+// containers coming first for each rule before falling back to the parent ones.
+// A local container shadows the parent containers sharing its name.
+//
+// The parent should be compiled before its children: only its materialized components
+// are inherited, they are neither part of the child Graph nor returned by its Containers method.
+// The child inherits the strict mode of its parent, unless the options override it.
+func (i *Inventory) Child(opts ...Option) *Inventory {
+	child := &Inventory{parent: i, strict: i.strict}
+	if err := Init(child, opts...); err != nil {
+		child.errs = append(child.errs, err)
+	}
+	return child
+}
+
+// scopes return the containers which can be assigned to the fields of the inventory,
+// from the local ones to the farthest ancestor ones.
+func (i *Inventory) scopes() [][]*container {
+	scopes := [][]*container{i.containers}
+	shadowed := make(map[string]bool, len(i.containers))
+	for _, container := range i.containers {
+		shadowed[container.name] = true
+	}
+
+	for parent := i.parent; parent != nil; parent = parent.parent {
+		var inherited []*container
+		for _, container := range parent.containers {
+			if !container.lazy && container.value != nil && !shadowed[container.name] {
+				inherited = append(inherited, container)
+			}
+		}
+		for _, container := range parent.containers {
+			shadowed[container.name] = true
+		}
+		scopes = append(scopes, inherited)
+	}
+	return scopes
+}
@@ -0,0 +1,56 @@
+package scaffolder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInventoryChild(t *testing.T) {
+	english, unused := &englishGreeter{}, &frenchGreeter{}
+	base := New().
+		Add(english, WithName("english")).
+		AddLazy(unused, WithName("unused")).
+		Add(&providedDatabase{dsn: "postgres://prod"}, WithName("database"))
+	if err := base.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	french, fake := &frenchGreeter{}, &providedDatabase{dsn: "postgres://test"}
+	registry := &greeterRegistry{}
+	user := &struct{ Database *providedDatabase }{}
+	child := base.Child().
+		Add(registry, WithName("registry")).
+		Add(fake, WithName("database")).
+		Add(french, WithName("french")).
+		Add(user, WithName("user"))
+	if err := child.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Database != fake {
+		t.Errorf("the local component should shadow the parent one: %+v", user.Database)
+	}
+	if !reflect.DeepEqual(registry.Greeters, []greeter{english, french}) {
+		t.Errorf("the materialized parent components should come first: %v", registry.Greeters)
+	}
+	if names := containerNamesOf(registry.Containers); !reflect.DeepEqual(names, []string{"english", "registry", "database", "french", "user"}) {
+		t.Errorf("the shadowed parent containers should be left out: %v", names)
+	}
+	if names := containerNamesOf(child.Containers()); !reflect.DeepEqual(names, []string{"registry", "database", "french", "user"}) {
+		t.Errorf("only the local containers should be returned: %v", names)
+	}
+	if names := containerNamesOf(child.Graph().Dependencies(child.Containers()[0])); !reflect.DeepEqual(names, []string{"french"}) {
+		t.Errorf("the parent containers should not be part of the child graph: %v", names)
+	}
+}
+
+func TestInventoryChildStrictMode(t *testing.T) {
+	base := New(WithStrictMode())
+	unmatched := OptionT[providedRepository](func(*providedRepository) error { return nil })
+
+	err := base.Child().Add(&providedDatabase{}, unmatched).Compile()
+	if !errors.Is(err, ErrUnmatchedOption) {
+		t.Errorf("the child should inherit the strict mode, got: %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package scaffolder
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// composite is implemented by the options built with the combinators,
+// unlike the functors they decide by themselves if they match the target.
+type composite interface {
+	// matches report if the option would apply to the target type.
+	matches(target reflect.Type) (bool, error)
+	// apply the option if it matches the target and report if it did.
+	apply(target reflect.Value) (bool, error)
+}
+
+func optionNames(opts []Option) string {
+	names := make([]string, len(opts))
+	for idx, opt := range opts {
+		names[idx] = optionName(opt)
+	}
+	return strings.Join(names, ", ")
+}
+
+type combined []Option
+
+// Combine group several options into one, every option matching the target is applied
+// and the combined option matches if at least one of them does.
+//
+//   func WithIdentity(first, last string) scaffolder.Option {
+//   	return scaffolder.Combine(FirstName(first), LastName(last))
+//   }
+func Combine(opts ...Option) Option {
+	return combined(opts)
+}
+
+func (c combined) matches(target reflect.Type) (bool, error) {
+	matched := false
+	for _, opt := range c {
+		ok, err := matchOption(opt, target)
+		if err != nil {
+			return false, err
+		}
+		matched = matched || ok
+	}
+	return matched, nil
+}
+
+func (c combined) apply(target reflect.Value) (bool, error) {
+	matched := false
+	for _, opt := range c {
+		ok, err := applyOption(opt, target)
+		matched = matched || ok
+		if err != nil {
+			return matched, err
+		}
+	}
+	return matched, nil
+}
+
+func (c combined) String() string {
+	return fmt.Sprintf("Combine(%s)", optionNames(c))
+}
+
+type conditional struct {
+	condition bool
+	opts      combined
+}
+
+// When apply the options only if the condition is true, the option still matches
+// the targets of the options when the condition is false, so it is not reported in strict mode.
+//
+//   scaffolder.When(*verbose, logger.WithLevel(logger.Debug))
+func When(condition bool, opts ...Option) Option {
+	return conditional{condition: condition, opts: opts}
+}
+
+func (c conditional) matches(target reflect.Type) (bool, error) {
+	return c.opts.matches(target)
+}
+
+func (c conditional) apply(target reflect.Value) (bool, error) {
+	if !c.condition {
+		return c.opts.matches(target.Type())
+	}
+	return c.opts.apply(target)
+}
+
+func (c conditional) String() string {
+	return fmt.Sprintf("When(%t, %s)", c.condition, optionNames(c.opts))
+}
+
+type unless struct {
+	field string
+	opts  combined
+}
+
+// Unless apply the options only if the field of the target still holds its zero value,
+// it is intended to not override a value which has already been set.
+// It only matches the targets which have such a field, the field promoted through
+// a nil embedded pointer is left untouched and the option reported as unmatched.
+//
+//   scaffolder.Unless("Interval", healthcheck.WithInterval(time.Second))
+func Unless(field string, opts ...Option) Option {
+	return unless{field: field, opts: opts}
+}
+
+func (u unless) structField(target reflect.Type) (reflect.StructField, bool) {
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	return target.Elem().FieldByName(u.field)
+}
+
+func (u unless) matches(target reflect.Type) (bool, error) {
+	if _, ok := u.structField(target); !ok {
+		return false, nil
+	}
+	return u.opts.matches(target)
+}
+
+func (u unless) apply(target reflect.Value) (bool, error) {
+	structField, ok := u.structField(target.Type())
+	if !ok {
+		return false, nil
+	}
+	fieldValue, ok := fieldByIndex(target.Elem(), structField.Index)
+	if !ok {
+		return false, nil
+	}
+	if !fieldValue.IsZero() {
+		return u.opts.matches(target.Type())
+	}
+	return u.opts.apply(target)
+}
+
+func (u unless) String() string {
+	return fmt.Sprintf("Unless(%s, %s)", u.field, optionNames(u.opts))
+}
+
+// fieldByIndex return the nested field of the structure, the fields promoted through
+// a nil embedded pointer can not be reached and are reported as missing.
+func fieldByIndex(structValue reflect.Value, index []int) (reflect.Value, bool) {
+	fieldValue, err := structValue.FieldByIndexErr(index)
+	return fieldValue, err == nil
+}
+
+type fieldOption struct {
+	name  string
+	value interface{}
+}
+
+// OptionsFrom lift the map into options assigning the values to the exported fields
+// of the same name, ignoring the case. The strings are converted the same way than
+// the default tags if the field is not a string, the other values must be
+// assignable or convertible to the field type.
+// Each option only matches the targets which have such a field, the field promoted through
+// a nil embedded pointer is left untouched and the option reported as unmatched.
+//
+//   scaffolder.Init(&form, scaffolder.OptionsFrom(map[string]interface{}{
+//   	"FirstName": "Erika",
+//   	"Age":       28,
+//   })...)
+func OptionsFrom(values map[string]interface{}) []Option {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	opts := make([]Option, len(names))
+	for idx, name := range names {
+		opts[idx] = fieldOption{name: name, value: values[name]}
+	}
+	return opts
+}
+
+func (f fieldOption) field(target reflect.Type) (reflect.StructField, bool) {
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	field, ok := target.Elem().FieldByNameFunc(func(name string) bool {
+		return strings.EqualFold(name, f.name)
+	})
+	return field, ok && field.PkgPath == ""
+}
+
+func (f fieldOption) matches(target reflect.Type) (bool, error) {
+	_, ok := f.field(target)
+	return ok, nil
+}
+
+func (f fieldOption) apply(target reflect.Value) (bool, error) {
+	field, ok := f.field(target.Type())
+	if !ok {
+		return false, nil
+	}
+	fieldValue, ok := fieldByIndex(target.Elem(), field.Index)
+	if !ok {
+		return false, nil
+	}
+	if f.value == nil {
+		fieldValue.Set(reflect.Zero(fieldValue.Type()))
+		return true, nil
+	}
+
+	value := reflect.ValueOf(f.value)
+	switch {
+	case value.Type().AssignableTo(fieldValue.Type()):
+		fieldValue.Set(value)
+	case value.Kind() == reflect.String && fieldValue.Kind() != reflect.String:
+		if err := SetString(fieldValue, value.String()); err != nil {
+			return true, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	case value.Kind() != reflect.String && fieldValue.Kind() == reflect.String,
+		!value.Type().ConvertibleTo(fieldValue.Type()):
+		return true, fmt.Errorf("field %s: %w: %s is not assignable to %s",
+			field.Name, ErrUnsupportedType, value.Type(), fieldValue.Type())
+	default:
+		fieldValue.Set(value.Convert(fieldValue.Type()))
+	}
+	return true, nil
+}
+
+func (f fieldOption) String() string {
+	return fmt.Sprintf("OptionsFrom(%s)", f.name)
+}
@@ -0,0 +1,173 @@
+package scaffolder
+
+import (
+	"errors"
+	"testing"
+)
+
+type combinedForm struct {
+	Name string
+	Age  int
+}
+
+func withFormName(name string) OptionT[combinedForm] {
+	return func(f *combinedForm) error {
+		f.Name = name
+		return nil
+	}
+}
+
+func withPrinterLevel(level string) OptionT[envPrinter] {
+	return func(p *envPrinter) error {
+		p.Level = level
+		return nil
+	}
+}
+
+// initializers are the ways to initialize a component, the strict ones report the unmatched options.
+var initializers = []struct {
+	name   string
+	strict bool
+	init   func(target Component, opts ...Option) error
+}{
+	{name: "Init", init: Init},
+	{name: "InitStrict", strict: true, init: InitStrict},
+	{
+		name: "Inventory.Add",
+		init: func(target Component, opts ...Option) error {
+			return New().Add(target, opts...).Compile()
+		},
+	},
+	{
+		name:   "Inventory.Add in strict mode",
+		strict: true,
+		init: func(target Component, opts ...Option) error {
+			return New(WithStrictMode()).Add(target, opts...).Compile()
+		},
+	},
+}
+
+func TestCombinators(t *testing.T) {
+	tests := []struct {
+		name      string
+		form      combinedForm
+		opt       Option
+		expected  combinedForm
+		unmatched bool
+	}{
+		{
+			name:     "Combine apply the matching options",
+			opt:      Combine(withFormName("Erika"), withPrinterLevel("debug")),
+			expected: combinedForm{Name: "Erika"},
+		},
+		{
+			name:      "Combine does not match if none of its options does",
+			opt:       Combine(withPrinterLevel("debug")),
+			unmatched: true,
+		},
+		{
+			name:     "When apply the options if the condition is true",
+			opt:      When(true, withFormName("Erika")),
+			expected: combinedForm{Name: "Erika"},
+		},
+		{
+			name: "When still matches if the condition is false",
+			opt:  When(false, withFormName("Erika")),
+		},
+		{
+			name:      "When does not match the other targets",
+			opt:       When(false, withPrinterLevel("debug")),
+			unmatched: true,
+		},
+		{
+			name:     "Unless apply the options if the field holds its zero value",
+			opt:      Unless("Name", withFormName("Erika")),
+			expected: combinedForm{Name: "Erika"},
+		},
+		{
+			name:     "Unless keeps the value already set",
+			form:     combinedForm{Name: "Max"},
+			opt:      Unless("Name", withFormName("Erika")),
+			expected: combinedForm{Name: "Max"},
+		},
+		{
+			name:      "Unless does not match the targets without the field",
+			opt:       Unless("Level", withFormName("Erika")),
+			unmatched: true,
+		},
+		{
+			name:     "OptionsFrom assign the fields ignoring the case",
+			opt:      Combine(OptionsFrom(map[string]interface{}{"name": "Erika", "AGE": "28"})...),
+			expected: combinedForm{Name: "Erika", Age: 28},
+		},
+		{
+			name:      "OptionsFrom does not match the targets without the field",
+			opt:       Combine(OptionsFrom(map[string]interface{}{"Level": "debug"})...),
+			unmatched: true,
+		},
+	}
+	for _, initializer := range initializers {
+		for _, test := range tests {
+			t.Run(initializer.name+"/"+test.name, func(t *testing.T) {
+				form := test.form
+				err := initializer.init(&form, test.opt)
+				if initializer.strict && test.unmatched {
+					if !errors.Is(err, ErrUnmatchedOption) {
+						t.Errorf("expected %v, got: %v", ErrUnmatchedOption, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if form != test.expected {
+					t.Errorf("expected %+v, got: %+v", test.expected, form)
+				}
+			})
+		}
+	}
+}
+
+func TestOptionsFromInvalidValue(t *testing.T) {
+	for _, initializer := range initializers {
+		t.Run(initializer.name, func(t *testing.T) {
+			err := initializer.init(&combinedForm{}, OptionsFrom(map[string]interface{}{"Age": "old"})...)
+			var initErr *InitError
+			if !errors.As(err, &initErr) || initErr.Option != "OptionsFrom(Age)" {
+				t.Errorf("expected the option to fail, got: %v", err)
+			}
+		})
+	}
+}
+
+type embeddedBase struct {
+	Name string
+}
+
+type embeddingForm struct {
+	*embeddedBase
+	Age int
+}
+
+func TestCombinatorsNilEmbeddedPointer(t *testing.T) {
+	opts := append(OptionsFrom(map[string]interface{}{"name": "Erika"}), Unless("Name", OptionsFrom(map[string]interface{}{"Age": 28})...))
+	for _, initializer := range initializers {
+		t.Run(initializer.name, func(t *testing.T) {
+			form := &embeddingForm{}
+			err := initializer.init(form, opts...)
+			if initializer.strict {
+				var unmatched *UnmatchedOptionError
+				if !errors.As(err, &unmatched) || len(unmatched.Options) != 2 {
+					t.Errorf("expected both options to be unmatched, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if form.embeddedBase != nil || form.Age != 0 {
+				t.Errorf("the form should be left untouched: %+v", form)
+			}
+		})
+	}
+}
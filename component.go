@@ -20,13 +20,28 @@ type Container interface {
 }
 
 type container struct {
-	value Component
-	name  string
-	t     reflect.Type
+	value  Component
+	name   string
+	t      reflect.Type
+	labels map[string]string
+	// named is true if the name has been given with WithName.
+	named bool
+
+	// hooks are run once the component and its container have been initialized.
+	hooks []func(*container) error
+	// provider builds the component while compiling the inventory, if it has been provided.
+	provider *provider
+	// lazy is true until the component is materialized, see Inventory.AddLazy.
+	lazy bool
+	// materialize initialize a lazy component added with AddLazy.
+	materialize func() error
 }
 
 func (c *container) Default() {
-	c.name = reflect.TypeOf(c.value).Elem().Name()
+	c.name = c.t.Name()
+	if c.t.Kind() == reflect.Ptr {
+		c.name = c.t.Elem().Name()
+	}
 }
 
 func (c *container) Name() string {
@@ -42,6 +57,29 @@ func (c *container) Component() Component {
 func WithName(name string) Option {
 	return func(c *container) error {
 		c.name = name
+		c.named = true
+		return nil
+	}
+}
+
+// WithLabel attach a label to a component, the fields can then select the components
+// having a label in their structure tag: `scaffolder:"label=region:eu"`.
+func WithLabel(key, value string) Option {
+	return func(c *container) error {
+		if c.labels == nil {
+			c.labels = make(map[string]string)
+		}
+		c.labels[key] = value
 		return nil
 	}
 }
+
+// hasLabels report if the container has every one of the given labels.
+func (c *container) hasLabels(labels map[string]string) bool {
+	for key, value := range labels {
+		if label, ok := c.labels[key]; !ok || label != value {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package healthcheck
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"sync"
 	"time"
@@ -22,8 +23,10 @@ func EveryService(mustBe Status, otherwise Status) MergingStrategy {
 	}
 }
 
+var ErrInvalidInterval = errors.New("the interval must be positive")
+
 type HTTPHandler struct {
-	Interval        time.Duration
+	Interval        time.Duration `default:"3s"`
 	Registry        HealthRegistry
 	MergingStrategy MergingStrategy
 
@@ -33,33 +36,80 @@ type HTTPHandler struct {
 
 func (h *HTTPHandler) Default() {
 	h.value = NotReady
-	h.Interval = 3 * time.Second
 	h.MergingStrategy = EveryService(Ready, NotReady)
 }
 
-func WithInterval(interval time.Duration) scaffolder.Option {
+func WithInterval(interval time.Duration) scaffolder.OptionT[HTTPHandler] {
 	return func(h *HTTPHandler) error {
+		if interval <= 0 {
+			return ErrInvalidInterval
+		}
 		h.Interval = interval
 		return nil
 	}
 }
 
-func WithMergingStrategy(strategy MergingStrategy) scaffolder.Option {
+func WithMergingStrategy(strategy MergingStrategy) scaffolder.OptionT[HTTPHandler] {
 	return func(h *HTTPHandler) error {
 		h.MergingStrategy = strategy
 		return nil
 	}
 }
 
+// Config define the configurable fields of the HTTPHandler.
+type Config struct {
+	Interval time.Duration `json:"interval"`
+}
+
+// Options implements the scaffolder.Configuration interface.
+func (c *Config) Options() []scaffolder.Option {
+	return []scaffolder.Option{
+		WithInterval(c.Interval),
+	}
+}
+
+// Configuration implements the scaffolder.Configurable interface.
+func (h *HTTPHandler) Configuration() scaffolder.Configuration {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return &Config{Interval: h.Interval}
+}
+
+// Reconfigure implements the scaffolder.Reconfigurable interface,
+// the new interval is used after the current one expires.
+func (h *HTTPHandler) Reconfigure(ctx context.Context, opts []scaffolder.Option) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	cp := &HTTPHandler{
+		Interval:        h.Interval,
+		Registry:        h.Registry,
+		MergingStrategy: h.MergingStrategy,
+	}
+	if err := scaffolder.Apply(cp, opts...); err != nil {
+		return err
+	}
+	h.Interval = cp.Interval
+	h.MergingStrategy = cp.MergingStrategy
+	return nil
+}
+
+func (h *HTTPHandler) settings() (time.Duration, MergingStrategy) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.Interval, h.MergingStrategy
+}
+
 func (h *HTTPHandler) Start(ctx context.Context) error {
 	go func() {
 		for {
-			h.setStatus(h.MergingStrategy(h.Registry.Services()))
+			interval, strategy := h.settings()
+			h.setStatus(strategy(h.Registry.Services()))
 
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(h.Interval):
+			case <-time.After(interval):
 			}
 		}
 	}()
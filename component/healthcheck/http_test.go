@@ -0,0 +1,23 @@
+package healthcheck
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Vorian-Atreides/scaffolder"
+)
+
+func TestWithIntervalError(t *testing.T) {
+	err := scaffolder.Init(&HTTPHandler{}, WithInterval(-1))
+
+	var initErr *scaffolder.InitError
+	if !errors.As(err, &initErr) || !errors.Is(err, ErrInvalidInterval) {
+		t.Fatalf("expected an InitError wrapping %v, got: %v", ErrInvalidInterval, err)
+	}
+	if name := "healthcheck.WithInterval(*healthcheck.HTTPHandler)"; initErr.Option != name {
+		t.Errorf("expected the option %q, got: %q", name, initErr.Option)
+	}
+	if initErr.Index != 0 {
+		t.Errorf("expected the option #0, got: %d", initErr.Index)
+	}
+}
@@ -4,16 +4,22 @@ the logger in your application.
 
 It extends the printing role from traditional logger with the ability
 to stack meta-data with entry log while maintaining an immutable state.
+
+The secrets tracked by the secret package are masked from the log entries and their meta-data.
 */
 package logger
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"sync/atomic"
 
 	"github.com/Vorian-Atreides/scaffolder"
+	"github.com/Vorian-Atreides/scaffolder/secret"
 )
 
 // MetaPrinter define the interface for logger which allows
@@ -59,6 +65,41 @@ const (
 	Error
 )
 
+// ErrUnknownLevel is returned if the text does not describe a verbosity level.
+var ErrUnknownLevel = errors.New("unknown level")
+
+var levelNames = map[Level]string{
+	Debug:   "debug",
+	Info:    "info",
+	Warning: "warning",
+	Error:   "error",
+}
+
+// String implements the Stringer interface.
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("Level(%d)", uint8(l))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface,
+// it accepts the level names ignoring the case: debug, info, warning or error.
+func (l *Level) UnmarshalText(text []byte) error {
+	for level, name := range levelNames {
+		if strings.EqualFold(name, string(text)) {
+			*l = level
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownLevel, text)
+}
+
 // we use a backward list to be able to efficiently stack the new entry
 // without modifying the parent reference.
 type entry struct {
@@ -79,8 +120,22 @@ func (e *entry) fields() map[string]interface{} {
 	return m
 }
 
+// atomicLevel is shared between a logger and the loggers derived with With,
+// so the level can be reconfigured while logging.
+type atomicLevel struct {
+	value uint32
+}
+
+func (a *atomicLevel) load() Level {
+	return Level(atomic.LoadUint32(&a.value))
+}
+
+func (a *atomicLevel) store(level Level) {
+	atomic.StoreUint32(&a.value, uint32(level))
+}
+
 type logger struct {
-	level Level
+	level *atomicLevel
 	inner Printer
 	meta  *entry
 }
@@ -93,14 +148,15 @@ func New(opts ...scaffolder.Option) Logger {
 }
 
 func (l *logger) Default() {
-	l.level = Debug
+	l.level = &atomicLevel{}
+	l.level.store(Debug)
 	l.inner = std
 }
 
 // WithLevel set the verbosity level to the new Logger.
 func WithLevel(level Level) scaffolder.Option {
 	return func(l *logger) error {
-		l.level = level
+		l.level.store(level)
 		return nil
 	}
 }
@@ -116,6 +172,35 @@ func WithPrinter(printer Printer) scaffolder.Option {
 	}
 }
 
+// Config define the configurable fields of the Logger.
+type Config struct {
+	Level Level `json:"level"`
+}
+
+// Options implements the scaffolder.Configuration interface.
+func (c *Config) Options() []scaffolder.Option {
+	return []scaffolder.Option{
+		WithLevel(c.Level),
+	}
+}
+
+// Configuration implements the scaffolder.Configurable interface.
+func (l *logger) Configuration() scaffolder.Configuration {
+	return &Config{Level: l.level.load()}
+}
+
+// Reconfigure implements the scaffolder.Reconfigurable interface,
+// only the level is updated and it affects every logger derived with With.
+func (l *logger) Reconfigure(ctx context.Context, opts []scaffolder.Option) error {
+	cp := &logger{level: &atomicLevel{}, inner: l.inner}
+	cp.level.store(l.level.load())
+	if err := scaffolder.Apply(cp, opts...); err != nil {
+		return err
+	}
+	l.level.store(cp.level.load())
+	return nil
+}
+
 func (l *logger) stringifyFields(fields map[string]interface{}) string {
 	var builder strings.Builder
 	for key, value := range fields {
@@ -129,23 +214,45 @@ func (l *logger) stringifyFields(fields map[string]interface{}) string {
 	return builder.String()
 }
 
+// redactValue return the meta-data value with its secrets masked, the values are kept as is
+// unless their formatted form contains a secret, such as an error or a fmt.Stringer.
+func redactValue(value interface{}) interface{} {
+	var text string
+	switch v := value.(type) {
+	case string:
+		return secret.Redact(v)
+	case []byte:
+		text = string(v)
+	default:
+		text = fmt.Sprintf("%v", v)
+	}
+	if redacted := secret.Redact(text); redacted != text {
+		return redacted
+	}
+	return value
+}
+
 func (l *logger) printf(level Level, format string, args ...interface{}) {
-	if level < l.level {
+	if level < l.level.load() {
 		return
 	}
 
+	message := secret.Redact(fmt.Sprintf(format, args...))
 	fields := l.meta.fields()
 	if len(fields) == 0 {
-		l.inner.Printf(format, args...)
+		l.inner.Printf("%s", message)
 		return
 	}
 
 	if m, ok := l.inner.(MetaPrinter); ok {
-		m.WithMeta(fields).Printf(format, args...)
+		for key, value := range fields {
+			fields[key] = redactValue(value)
+		}
+		m.WithMeta(fields).Printf("%s", message)
 		return
 	}
-	stringifiedFields := l.stringifyFields(fields)
-	l.inner.Printf(format+"\t%s", append(args, stringifiedFields)...)
+	stringifiedFields := secret.Redact(l.stringifyFields(fields))
+	l.inner.Printf("%s\t%s", message, stringifiedFields)
 }
 
 func (l *logger) Printf(format string, args ...interface{}) {
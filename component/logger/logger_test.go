@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Vorian-Atreides/scaffolder/secret"
+)
+
+// metaPrinter record the meta-data given to WithMeta.
+type metaPrinter struct {
+	meta map[string]interface{}
+}
+
+func (p *metaPrinter) Printf(format string, args ...interface{}) {}
+
+func (p *metaPrinter) WithMeta(meta map[string]interface{}) Logger {
+	p.meta = meta
+	return New(WithPrinter(p))
+}
+
+type dsn struct {
+	password string
+}
+
+func (d dsn) String() string { return "postgres://admin:" + d.password + "@localhost" }
+
+func TestLoggerRedactsTheMeta(t *testing.T) {
+	secret.Track("s3cr3t-passw0rd")
+	printer := &metaPrinter{}
+
+	New(WithPrinter(printer)).
+		With("error", errors.New("authentication failed for s3cr3t-passw0rd")).
+		With("dsn", dsn{password: "s3cr3t-passw0rd"}).
+		With("body", []byte("password=s3cr3t-passw0rd")).
+		With("password", "s3cr3t-passw0rd").
+		With("attempts", 3).
+		Infof("connecting")
+
+	for key, value := range printer.meta {
+		text := fmt.Sprintf("%v", value)
+		if b, ok := value.([]byte); ok {
+			text = string(b)
+		}
+		if strings.Contains(text, "s3cr3t-passw0rd") {
+			t.Errorf("the secret has not been masked from %s: %v", key, value)
+		}
+	}
+	if printer.meta["attempts"] != 3 {
+		t.Errorf("the values without secret should be kept as is: %#v", printer.meta["attempts"])
+	}
+}
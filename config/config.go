@@ -0,0 +1,291 @@
+/*
+Package config define a configuration Loader which fills the configuration structures
+from several layers, each layer overriding the values of the previous one:
+
+  1. The default tags and the Defaulter interfaces, as applied by scaffolder.Init.
+  2. The configuration files, in the order they were given to the Loader.
+  3. The environment specific variant of each file, such as config.production.yaml.
+  4. The environment variables, as loaded by scaffolder.LoadEnv.
+  5. The command line flags which have been set.
+
+The JSON, YAML and TOML formats are supported out of the box and are selected from
+the file extension, new formats can be added with RegisterDecoder.
+
+A configuration file may hold the configuration of several components, each one
+in its own top-level section:
+
+  readiness:
+    interval: 1s
+  logger:
+    level: info
+
+The string values referencing a secret, such as file:///run/secrets/db_password
+or env://DB_PASS, are resolved with the secret package before being assigned.
+
+The keys are matched against the `config`, `json`, `yaml`, `toml` or `flag` tags
+of the fields or against their name, ignoring the case. The keys of the files and the flags
+which do not match any field are ignored, they might belong to another component
+or to a newer version of the configuration; the FlagSet already rejects the undefined flags.
+*/
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/Vorian-Atreides/scaffolder"
+)
+
+type file struct {
+	path     string
+	optional bool
+}
+
+type document struct {
+	source string
+	values map[string]interface{}
+}
+
+// Loader load the configuration structures from the files,
+// the environment variables and the command line flags.
+type Loader struct {
+	files       []file
+	environment string
+	envPrefix   string
+	flags       *flag.FlagSet
+
+	documents []document
+	read      bool
+}
+
+// New build a Loader and customize it with the given Options.
+func New(opts ...scaffolder.Option) (*Loader, error) {
+	loader := &Loader{}
+	return loader, scaffolder.Init(loader, opts...)
+}
+
+// WithFile add a configuration file to the Loader,
+// the files are loaded in the same order than they were given.
+func WithFile(path string) scaffolder.Option {
+	return func(l *Loader) error {
+		l.files = append(l.files, file{path: path})
+		return nil
+	}
+}
+
+// WithOptionalFile add a configuration file which is skipped if it does not exist.
+func WithOptionalFile(path string) scaffolder.Option {
+	return func(l *Loader) error {
+		l.files = append(l.files, file{path: path, optional: true})
+		return nil
+	}
+}
+
+// WithEnvironment load, right after every configuration file, its optional environment
+// specific variant: config.yaml would be followed by config.production.yaml.
+func WithEnvironment(environment string) scaffolder.Option {
+	return func(l *Loader) error {
+		l.environment = environment
+		return nil
+	}
+}
+
+// WithEnvPrefix set the prefix of the environment variables,
+// it is joined to the prefix derived from the section name.
+func WithEnvPrefix(prefix string) scaffolder.Option {
+	return func(l *Loader) error {
+		l.envPrefix = prefix
+		return nil
+	}
+}
+
+// WithFlags override the configuration with the flags which have been set,
+// the flag names are the dot separated path of the keys: readiness.interval.
+// The FlagSet must be parsed before loading the configuration.
+func WithFlags(flags *flag.FlagSet) scaffolder.Option {
+	return func(l *Loader) error {
+		l.flags = flags
+		return nil
+	}
+}
+
+func (l *Loader) environmentPath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + l.environment + ext
+}
+
+func (l *Loader) readFile(f file) error {
+	data, err := os.ReadFile(f.path)
+	switch {
+	case os.IsNotExist(err) && f.optional:
+		return nil
+	case err != nil:
+		return err
+	}
+
+	decoder, err := decoderFor(f.path)
+	if err != nil {
+		return err
+	}
+	values := map[string]interface{}{}
+	if err := decoder(data, &values); err != nil {
+		return &Error{Source: f.path, Err: err}
+	}
+	l.documents = append(l.documents, document{source: f.path, values: values})
+	return nil
+}
+
+// Files return the path of every configuration file the Loader reads, in the order
+// they are read, including the environment specific variants and the optional files
+// which might not exist yet.
+func (l *Loader) Files() []string {
+	var paths []string
+	for _, f := range l.files {
+		paths = append(paths, f.path)
+		if l.environment != "" {
+			paths = append(paths, l.environmentPath(f.path))
+		}
+	}
+	return paths
+}
+
+// Read (re)read every configuration file, it is called on the first Load
+// and should be called again to take the changes of the files into account.
+func (l *Loader) Read() error {
+	l.documents = nil
+	for _, f := range l.files {
+		if err := l.readFile(f); err != nil {
+			return err
+		}
+		if l.environment != "" {
+			variant := file{path: l.environmentPath(f.path), optional: true}
+			if err := l.readFile(variant); err != nil {
+				return err
+			}
+		}
+	}
+	l.read = true
+	return nil
+}
+
+// Has report if a configuration file or a flag define the given section.
+func (l *Loader) Has(section string) (bool, error) {
+	if !l.read {
+		if err := l.Read(); err != nil {
+			return false, err
+		}
+	}
+	for _, doc := range l.documents {
+		if _, ok := lookup(doc.values, section); ok {
+			return true, nil
+		}
+	}
+
+	found := false
+	if l.flags != nil {
+		l.flags.Visit(func(f *flag.Flag) {
+			found = found || hasPrefixFold(f.Name, section+keySeparator)
+		})
+	}
+	return found, nil
+}
+
+func (l *Loader) envSectionPrefix(section string) string {
+	prefix := scaffolder.EnvPrefix(section)
+	switch {
+	case l.envPrefix == "":
+		return prefix
+	case prefix == "":
+		return l.envPrefix
+	}
+	return l.envPrefix + "_" + prefix
+}
+
+// Load fills the configuration structure with every layer, the section select
+// a top-level key of the configuration files, an empty section selects the whole file.
+// The configuration is then checked against its validate tags, see scaffolder.Validate.
+func (l *Loader) Load(section string, cfg interface{}) error {
+	value := reflect.ValueOf(cfg)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return scaffolder.ErrInvalidTarget
+	}
+	if !l.read {
+		if err := l.Read(); err != nil {
+			return err
+		}
+	}
+
+	if err := scaffolder.Init(cfg); err != nil {
+		return err
+	}
+	for _, doc := range l.documents {
+		var raw interface{} = doc.values
+		if section != "" {
+			var ok bool
+			if raw, ok = lookup(doc.values, section); !ok {
+				continue
+			}
+		}
+		d := decoder{source: doc.source}
+		if err := d.decode(value.Elem(), raw, section); err != nil {
+			return err
+		}
+	}
+	if err := scaffolder.LoadEnv(cfg, l.envSectionPrefix(section)); err != nil {
+		return err
+	}
+	if err := l.loadFlags(value.Elem(), section); err != nil {
+		return err
+	}
+	return scaffolder.Validate(cfg)
+}
+
+func (l *Loader) loadFlags(value reflect.Value, section string) (err error) {
+	if l.flags == nil {
+		return nil
+	}
+
+	prefix := ""
+	if section != "" {
+		prefix = section + keySeparator
+	}
+	l.flags.Visit(func(f *flag.Flag) {
+		if err != nil || !hasPrefixFold(f.Name, prefix) {
+			return
+		}
+		d := decoder{source: "flag -" + f.Name}
+		err = d.set(value, f.Name[len(prefix):], f.Value.String(), section)
+	})
+	return err
+}
+
+// Configure load the section into the configuration and configure the target with it.
+func (l *Loader) Configure(target scaffolder.Component, section string, cfg scaffolder.Configuration) error {
+	if err := l.Load(section, cfg); err != nil {
+		return err
+	}
+	return scaffolder.Configure(target, cfg)
+}
+
+// Error describe which file and key could not be loaded.
+type Error struct {
+	Source string
+	Key    string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s: %s", e.Source, e.Err)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Source, e.Key, e.Err)
+}
+
+// Unwrap return the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
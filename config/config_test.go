@@ -0,0 +1,190 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Vorian-Atreides/scaffolder"
+)
+
+type serverConfig struct {
+	Host     string        `json:"host" default:"localhost"`
+	Port     int           `json:"port" env:"PORT"`
+	Interval time.Duration `json:"interval" env:"INTERVAL"`
+	Level    string        `json:"level" env:"LEVEL"`
+	Hosts    []string      `json:"hosts"`
+	Labels   map[string]string
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoaderLayers(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFile(t, dir, "config.yaml", `
+server:
+  port: 8080
+  interval: 1s
+  level: info
+  hosts: [a, b]
+  labels:
+    region: eu
+`)
+	writeFile(t, dir, "config.production.yaml", `
+server:
+  interval: 2s
+  level: warning
+  labels:
+    tier: front
+`)
+	t.Setenv("APP_SERVER_INTERVAL", "3s")
+	t.Setenv("APP_SERVER_LEVEL", "error")
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.String("server.level", "", "")
+	flags.String("other.key", "", "")
+	if err := flags.Parse([]string{"-server.level=debug", "-other.key=ignored"}); err != nil {
+		t.Fatal(err)
+	}
+
+	loader, err := New(WithFile(base), WithEnvironment("production"), WithEnvPrefix("APP"), WithFlags(flags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg serverConfig
+	if err := loader.Load("server", &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := serverConfig{
+		// The default tag.
+		Host: "localhost",
+		// The base file.
+		Port:  8080,
+		Hosts: []string{"a", "b"},
+		// The environment file merged over the base one.
+		Labels: map[string]string{"region": "eu", "tier": "front"},
+		// The environment variables.
+		Interval: 3 * time.Second,
+		// The flags.
+		Level: "debug",
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("expected %+v, got: %+v", expected, cfg)
+	}
+}
+
+func TestLoaderFormats(t *testing.T) {
+	expected := serverConfig{Host: "example.com", Port: 8080, Interval: time.Second, Hosts: []string{"a", "b"}}
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "config.json",
+			content: `{"server": {"host": "example.com", "port": 8080, "interval": "1s", "hosts": ["a", "b"]}}`,
+		},
+		{
+			name:    "config.yaml",
+			content: "server:\n  host: example.com\n  port: 8080\n  interval: 1s\n  hosts: [a, b]\n",
+		},
+		{
+			name:    "config.toml",
+			content: "[server]\nhost = \"example.com\"\nport = 8080\ninterval = \"1s\"\nhosts = [\"a\", \"b\"]\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loader, err := New(WithFile(writeFile(t, t.TempDir(), test.name, test.content)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cfg serverConfig
+			if err := loader.Load("server", &cfg); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cfg, expected) {
+				t.Errorf("expected %+v, got: %+v", expected, cfg)
+			}
+		})
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := writeFile(t, dir, "invalid.json", `{"server": {"port": "eighty"}}`)
+	malformed := writeFile(t, dir, "malformed.yaml", "server: [\n")
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.String("server.interval", "", "")
+	if err := flags.Parse([]string{"-server.interval=soon"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		opts   []scaffolder.Option
+		source string
+		key    string
+	}{
+		{name: "invalid value", opts: []scaffolder.Option{WithFile(invalid)}, source: invalid, key: "server.port"},
+		{name: "malformed file", opts: []scaffolder.Option{WithFile(malformed)}, source: malformed},
+		{name: "invalid flag", opts: []scaffolder.Option{WithFlags(flags)}, source: "flag -server.interval", key: "server.interval"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loader, err := New(test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cfgErr *Error
+			if err := loader.Load("server", &serverConfig{}); !errors.As(err, &cfgErr) {
+				t.Fatalf("expected an Error, got: %v", err)
+			}
+			if cfgErr.Source != test.source || cfgErr.Key != test.key {
+				t.Errorf("expected %s: %s, got: %s: %s", test.source, test.key, cfgErr.Source, cfgErr.Key)
+			}
+		})
+	}
+}
+
+func TestLoaderUnknownKeys(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "server:\n  port: 8080\n  unknown: true\n")
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.String("server.unknown", "", "")
+	if err := flags.Parse([]string{"-server.unknown=true"}); err != nil {
+		t.Fatal(err)
+	}
+
+	loader, err := New(WithFile(path), WithFlags(flags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg serverConfig
+	if err := loader.Load("server", &cfg); err != nil {
+		t.Fatalf("the unknown keys of the files and the flags should be ignored: %v", err)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("the known keys should still be loaded: %+v", cfg)
+	}
+}
+
+func TestLoaderFiles(t *testing.T) {
+	loader, err := New(WithFile("config.yaml"), WithOptionalFile("local.json"), WithEnvironment("production"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"config.yaml", "config.production.yaml", "local.json", "local.production.json"}
+	if files := loader.Files(); !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, got: %v", expected, files)
+	}
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Vorian-Atreides/scaffolder"
+	"github.com/Vorian-Atreides/scaffolder/secret"
+)
+
+const keySeparator = "."
+
+var keyTags = []string{"config", "json", "yaml", "toml", "flag"}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + keySeparator + key
+}
+
+// lookup search the key in the document ignoring the case.
+func lookup(values map[string]interface{}, key string) (interface{}, bool) {
+	if value, ok := values[key]; ok {
+		return value, true
+	}
+	for k, value := range values {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// matchKey report if the key match the structure field, either by one of its tags
+// or by its name, ignoring the case.
+func matchKey(field reflect.StructField, key string) bool {
+	for _, tag := range keyTags {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name != "" && name != "-" && strings.EqualFold(name, key) {
+			return true
+		}
+	}
+	return strings.EqualFold(field.Name, key)
+}
+
+// fieldOf search the exported field matching the key.
+func fieldOf(structValue reflect.Value, key string) (reflect.Value, bool) {
+	structType := structValue.Type()
+	for y := 0; y < structType.NumField(); y++ {
+		fieldValue := structValue.Field(y)
+		if fieldValue.CanSet() && matchKey(structType.Field(y), key) {
+			return fieldValue, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// decoder assign the values decoded from a configuration file, as maps, slices
+// and scalars, to the configuration structure.
+type decoder struct {
+	source string
+}
+
+func (d decoder) errorf(path string, format string, args ...interface{}) error {
+	return &Error{Source: d.source, Key: path, Err: fmt.Errorf(format, args...)}
+}
+
+func (d decoder) decode(value reflect.Value, raw interface{}, path string) error {
+	if raw == nil {
+		return nil
+	}
+	if str, ok := raw.(string); ok {
+		resolved, err := secret.Resolve(str)
+		if err != nil {
+			return d.errorf(path, "%w", err)
+		}
+		raw = resolved
+	}
+	rawValue := reflect.ValueOf(raw)
+	if rawValue.Type().AssignableTo(value.Type()) {
+		value.Set(rawValue)
+		return nil
+	}
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		return d.decodeMap(value, typed, path)
+	case map[interface{}]interface{}:
+		values := make(map[string]interface{}, len(typed))
+		for key, v := range typed {
+			values[fmt.Sprint(key)] = v
+		}
+		return d.decodeMap(value, values, path)
+	case []interface{}:
+		return d.decodeSlice(value, typed, path)
+	case []map[string]interface{}:
+		items := make([]interface{}, len(typed))
+		for idx, item := range typed {
+			items[idx] = item
+		}
+		return d.decodeSlice(value, items, path)
+	case string:
+		return d.setString(value, typed, path)
+	case float64:
+		return d.setString(value, strconv.FormatFloat(typed, 'f', -1, 64), path)
+	case float32:
+		return d.setString(value, strconv.FormatFloat(float64(typed), 'f', -1, 32), path)
+	}
+	return d.setString(value, fmt.Sprint(raw), path)
+}
+
+func (d decoder) decodeMap(value reflect.Value, values map[string]interface{}, path string) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return d.decodeMap(value.Elem(), values, path)
+	case reflect.Struct:
+		for key, raw := range values {
+			field, ok := fieldOf(value, key)
+			if !ok {
+				continue
+			}
+			if err := d.decode(field, raw, joinKey(path, key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		for key, raw := range values {
+			k := reflect.New(value.Type().Key()).Elem()
+			if err := scaffolder.SetString(k, key); err != nil {
+				return d.errorf(joinKey(path, key), "%w", err)
+			}
+			// Overlay the existing entry, so the layers can be merged.
+			elem := reflect.New(value.Type().Elem()).Elem()
+			if existing := value.MapIndex(k); existing.IsValid() {
+				elem.Set(existing)
+			}
+			if err := d.decode(elem, raw, joinKey(path, key)); err != nil {
+				return err
+			}
+			value.SetMapIndex(k, elem)
+		}
+	default:
+		return d.errorf(path, "cannot decode a section into %s", value.Type())
+	}
+	return nil
+}
+
+func (d decoder) decodeSlice(value reflect.Value, items []interface{}, path string) error {
+	if value.Kind() != reflect.Slice {
+		return d.errorf(path, "cannot decode a list into %s", value.Type())
+	}
+
+	slice := reflect.MakeSlice(value.Type(), len(items), len(items))
+	for idx, item := range items {
+		if err := d.decode(slice.Index(idx), item, fmt.Sprintf("%s[%d]", path, idx)); err != nil {
+			return err
+		}
+	}
+	value.Set(slice)
+	return nil
+}
+
+func (d decoder) setString(value reflect.Value, raw string, path string) error {
+	if err := scaffolder.SetString(value, raw); err != nil {
+		return d.errorf(path, "%w", err)
+	}
+	return nil
+}
+
+// set resolve the raw string and convert it into the field designated by the dot separated keys.
+func (d decoder) set(value reflect.Value, keys string, raw string, path string) error {
+	for _, key := range strings.Split(keys, keySeparator) {
+		if key == "" {
+			continue
+		}
+		path = joinKey(path, key)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return d.errorf(path, "%s has no key %q", value.Type(), key)
+		}
+
+		field, ok := fieldOf(value, key)
+		if !ok {
+			// The unknown keys are ignored the same way than in the files.
+			return nil
+		}
+		value = field
+	}
+
+	resolved, err := secret.Resolve(raw)
+	if err != nil {
+		return d.errorf(path, "%w", err)
+	}
+	return d.setString(value, resolved, path)
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownFormat is returned if no Decoder or Encoder has been registered for the format.
+var ErrUnknownFormat = errors.New("unknown configuration format")
+
+// Decoder unmarshal the content of a configuration file.
+type Decoder func(data []byte, v interface{}) error
+
+var decoders = map[string]Decoder{
+	".json": json.Unmarshal,
+	".yaml": yaml.Unmarshal,
+	".yml":  yaml.Unmarshal,
+	".toml": toml.Unmarshal,
+}
+
+// RegisterDecoder add or replace the Decoder used for the files with the given extension,
+// such as ".hcl". It is not safe to call it concurrently with a Loader.
+func RegisterDecoder(extension string, decoder Decoder) {
+	decoders[strings.ToLower(extension)] = decoder
+}
+
+func decoderFor(path string) (Decoder, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	decoder, ok := decoders[ext]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+	return decoder, nil
+}
+
+// Encoder write the value in a configuration format.
+type Encoder func(w io.Writer, v interface{}) error
+
+var encoders = map[string]Encoder{
+	"json": func(w io.Writer, v interface{}) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	},
+	"yaml": func(w io.Writer, v interface{}) error {
+		encoder := yaml.NewEncoder(w)
+		defer encoder.Close()
+		return encoder.Encode(v)
+	},
+	"toml": func(w io.Writer, v interface{}) error {
+		return toml.NewEncoder(w).Encode(v)
+	},
+}
+
+// RegisterEncoder add or replace the Encoder used for the given format.
+// It is not safe to call it concurrently with Encode.
+func RegisterEncoder(format string, encoder Encoder) {
+	encoders[strings.ToLower(format)] = encoder
+}
+
+// Encode write the value in the given format: json, yaml or toml.
+func Encode(w io.Writer, format string, v interface{}) error {
+	encoder, ok := encoders[strings.ToLower(format)]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+	return encoder(w, v)
+}
@@ -0,0 +1,141 @@
+package scaffolder
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnsupportedType is returned if a raw value could not be converted
+	// into the type of a field.
+	ErrUnsupportedType = errors.New("unsupported type")
+
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+const (
+	itemSeparator     = ","
+	keyValueSeparator = ":"
+)
+
+// isNested report if the value is a structure whose fields should be walked through
+// rather than converted as a whole.
+func isNested(value reflect.Value) bool {
+	return value.Kind() == reflect.Struct &&
+		!reflect.PtrTo(value.Type()).Implements(textUnmarshalerType)
+}
+
+// SetString convert the raw string into the type of the value before assigning it.
+//
+// Besides the basic types, it supports time.Duration, the encoding.TextUnmarshaler interface,
+// the slices written as comma separated items: "a,b,c"
+// and the maps written as comma separated key value pairs: "a:1,b:2".
+func SetString(value reflect.Value, raw string) error {
+	if value.CanAddr() {
+		if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return unmarshaler.UnmarshalText([]byte(raw))
+		}
+	}
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 0, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 0, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		return setSlice(value, raw)
+	case reflect.Map:
+		return setMap(value, raw)
+	case reflect.Ptr:
+		elem := reflect.New(value.Type().Elem())
+		if err := SetString(elem.Elem(), raw); err != nil {
+			return err
+		}
+		value.Set(elem)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, value.Type())
+	}
+	return nil
+}
+
+func split(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	items := strings.Split(raw, itemSeparator)
+	for idx, item := range items {
+		items[idx] = strings.TrimSpace(item)
+	}
+	return items
+}
+
+func setSlice(value reflect.Value, raw string) error {
+	items := split(raw)
+	slice := reflect.MakeSlice(value.Type(), len(items), len(items))
+	for idx, item := range items {
+		if err := SetString(slice.Index(idx), item); err != nil {
+			return err
+		}
+	}
+	value.Set(slice)
+	return nil
+}
+
+func setMap(value reflect.Value, raw string) error {
+	mapType := value.Type()
+	m := reflect.MakeMap(mapType)
+	for _, item := range split(raw) {
+		pair := strings.SplitN(item, keyValueSeparator, 2)
+		if len(pair) != 2 {
+			return fmt.Errorf("invalid key value pair %q", item)
+		}
+
+		key := reflect.New(mapType.Key()).Elem()
+		if err := SetString(key, strings.TrimSpace(pair[0])); err != nil {
+			return err
+		}
+		elem := reflect.New(mapType.Elem()).Elem()
+		if err := SetString(elem, strings.TrimSpace(pair[1])); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, elem)
+	}
+	value.Set(m)
+	return nil
+}
@@ -0,0 +1,81 @@
+package scaffolder
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+const defaultTag = "default"
+
+// Defaulter optional interface which can be used to attach default values
+// to a component.
+type Defaulter interface {
+	Default()
+}
+
+// DefaulterE is the counterpart of the Defaulter interface for components
+// whose default values might not be computed, such as the hostname.
+type DefaulterE interface {
+	Default() error
+}
+
+// ContextDefaulter is the counterpart of the DefaulterE interface for components
+// whose default values depend on the context given to InitContext.
+type ContextDefaulter interface {
+	Default(context.Context) error
+}
+
+// setDefaults assign the values of the default tags before calling the
+// Defaulter interfaces, the default method can therefore override them.
+func setDefaults(ctx context.Context, target Component) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct {
+		if err := setTagDefaults(value.Elem(), value.Elem().Type().Name()); err != nil {
+			return err
+		}
+	}
+
+	switch defaulter := target.(type) {
+	case Defaulter:
+		defaulter.Default()
+	case DefaulterE:
+		return defaulter.Default()
+	case ContextDefaulter:
+		return defaulter.Default(ctx)
+	}
+	return nil
+}
+
+// setTagDefaults walk through the exported fields of the structure and assign
+// the value of their default tag, if they still hold their zero value.
+//
+//   type HTTPHandler struct {
+//   	Interval time.Duration     `default:"3s"`
+//   	Methods  []string          `default:"GET,HEAD"`
+//   	Headers  map[string]string `default:"Cache-Control:no-cache"`
+//   }
+func setTagDefaults(structValue reflect.Value, path string) error {
+	structType := structValue.Type()
+	for y := 0; y < structType.NumField(); y++ {
+		fieldType := structType.Field(y)
+		fieldValue := structValue.Field(y)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		fieldPath := path + "." + fieldType.Name
+		raw, ok := fieldType.Tag.Lookup(defaultTag)
+		switch {
+		case ok && fieldValue.IsZero():
+			if err := SetString(fieldValue, raw); err != nil {
+				return fmt.Errorf("invalid default value for %s: %w", fieldPath, err)
+			}
+		case !ok && isNested(fieldValue):
+			if err := setTagDefaults(fieldValue, fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package scaffolder
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type retryPolicy struct {
+	Attempts int           `default:"3"`
+	Backoff  time.Duration `default:"250ms"`
+}
+
+type serverSettings struct {
+	Timeout   time.Duration     `default:"1m30s"`
+	Port      int               `default:"8080"`
+	Mask      uint32            `default:"0x1F"`
+	Ratio     float64           `default:"0.75"`
+	Debug     bool              `default:"true"`
+	Methods   []string          `default:"GET, HEAD"`
+	Weights   []int             `default:"1,2,3"`
+	Headers   map[string]string `default:"Cache-Control:no-cache,Pragma:no-cache"`
+	Limits    map[string]int    `default:"read:10, write:5"`
+	Name      *string           `default:"api"`
+	Address   net.IP            `default:"127.0.0.1"`
+	Retry     retryPolicy
+	Untouched string
+
+	internal string `default:"ignored"`
+}
+
+func TestTagDefaults(t *testing.T) {
+	settings := &serverSettings{}
+	if err := Init(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	name := "api"
+	expected := &serverSettings{
+		Timeout: 90 * time.Second,
+		Port:    8080,
+		Mask:    31,
+		Ratio:   0.75,
+		Debug:   true,
+		Methods: []string{"GET", "HEAD"},
+		Weights: []int{1, 2, 3},
+		Headers: map[string]string{"Cache-Control": "no-cache", "Pragma": "no-cache"},
+		Limits:  map[string]int{"read": 10, "write": 5},
+		Name:    &name,
+		Address: net.ParseIP("127.0.0.1"),
+		Retry:   retryPolicy{Attempts: 3, Backoff: 250 * time.Millisecond},
+	}
+	if !reflect.DeepEqual(settings, expected) {
+		t.Errorf("expected %+v, got: %+v", expected, settings)
+	}
+}
+
+func TestTagDefaultsKeepTheExistingValues(t *testing.T) {
+	settings := &serverSettings{Port: 9090, Methods: []string{"POST"}, Retry: retryPolicy{Attempts: 1}}
+	if err := Init(settings); err != nil {
+		t.Fatal(err)
+	}
+	if settings.Port != 9090 || !reflect.DeepEqual(settings.Methods, []string{"POST"}) {
+		t.Errorf("the existing values should be kept: %d, %v", settings.Port, settings.Methods)
+	}
+	if settings.Retry.Attempts != 1 || settings.Retry.Backoff != 250*time.Millisecond {
+		t.Errorf("only the zero values of the nested structure should be assigned: %+v", settings.Retry)
+	}
+}
+
+func TestTagDefaultsRunBeforeTheDefaulter(t *testing.T) {
+	store := &fakeStore{}
+	if err := Init(store); err != nil {
+		t.Fatal(err)
+	}
+	if store.Prefix != "fake" || store.Mode != "memory" {
+		t.Errorf("both the tag and the Default method should be applied: %+v", store)
+	}
+}
+
+type invalidDuration struct {
+	Timeout time.Duration `default:"soon"`
+}
+
+type invalidNumber struct {
+	Workers uint8 `default:"-1"`
+}
+
+type invalidNestedBool struct {
+	Retry struct {
+		Enabled bool `default:"maybe"`
+	}
+}
+
+type invalidPair struct {
+	Headers map[string]string `default:"no-separator"`
+}
+
+type unsupportedDefault struct {
+	Handler func() `default:"noop"`
+}
+
+func TestTagDefaultsErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		component Component
+		target    error
+		message   string
+	}{
+		{
+			name:      "invalid duration",
+			component: &invalidDuration{},
+			message:   "*scaffolder.invalidDuration: invalid default value for invalidDuration.Timeout: time: invalid duration \"soon\"",
+		},
+		{
+			name:      "negative unsigned number",
+			component: &invalidNumber{},
+			target:    strconv.ErrSyntax,
+		},
+		{
+			name:      "invalid boolean in a nested structure",
+			component: &invalidNestedBool{},
+			target:    strconv.ErrSyntax,
+			message:   "*scaffolder.invalidNestedBool: invalid default value for invalidNestedBool.Retry.Enabled: strconv.ParseBool: parsing \"maybe\": invalid syntax",
+		},
+		{
+			name:      "invalid key value pair",
+			component: &invalidPair{},
+			message:   "*scaffolder.invalidPair: invalid default value for invalidPair.Headers: invalid key value pair \"no-separator\"",
+		},
+		{
+			name:      "unsupported type",
+			component: &unsupportedDefault{},
+			target:    ErrUnsupportedType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Init(test.component)
+
+			var initErr *InitError
+			if !errors.As(err, &initErr) {
+				t.Fatalf("expected an InitError, got: %v", err)
+			}
+			if initErr.Component != reflect.TypeOf(test.component) || initErr.Index != -1 {
+				t.Errorf("the error should point at the component: %+v", initErr)
+			}
+			if test.target != nil && !errors.Is(err, test.target) {
+				t.Errorf("expected %v, got: %v", test.target, err)
+			}
+			if test.message != "" && err.Error() != test.message {
+				t.Errorf("expected %q, got: %q", test.message, err.Error())
+			}
+		})
+	}
+}
+
+var (
+	errNoHostname = errors.New("no hostname")
+	errNoRegion   = errors.New("no region")
+)
+
+type hostnameDefaulter struct {
+	Hostname string
+}
+
+func (h *hostnameDefaulter) Default() error { return errNoHostname }
+
+type contextKey struct{}
+
+type contextDefaulter struct {
+	Region string
+}
+
+func (c *contextDefaulter) Default(ctx context.Context) error {
+	region, ok := ctx.Value(contextKey{}).(string)
+	if !ok {
+		return errNoRegion
+	}
+	c.Region = region
+	return nil
+}
+
+func TestDefaulterErrors(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey{}, "eu")
+	defaulter := &contextDefaulter{}
+	if err := InitContext(ctx, defaulter); err != nil {
+		t.Fatal(err)
+	}
+	if defaulter.Region != "eu" {
+		t.Errorf("the context should be given to the defaulter: %q", defaulter.Region)
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+	}{
+		{name: "Init with a DefaulterE", err: Init(&hostnameDefaulter{}), target: errNoHostname},
+		{name: "Init with a ContextDefaulter", err: Init(&contextDefaulter{}), target: errNoRegion},
+		{
+			name:   "Inventory with a DefaulterE",
+			err:    New().AddContext(ctx, &hostnameDefaulter{}, WithName("hostname")).Compile(),
+			target: errNoHostname,
+		},
+		{
+			name:   "Inventory with a ContextDefaulter",
+			err:    New().AddContext(context.Background(), &contextDefaulter{}, WithName("region")).Compile(),
+			target: errNoRegion,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var initErr *InitError
+			if !errors.As(test.err, &initErr) || !errors.Is(test.err, test.target) {
+				t.Fatalf("expected an InitError wrapping %v, got: %v", test.target, test.err)
+			}
+			if initErr.Index != -1 {
+				t.Errorf("the error should not be attributed to an option: %+v", initErr)
+			}
+		})
+	}
+
+	inventory := New().AddContext(ctx, &contextDefaulter{}, WithName("region"))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if resolved, err := ResolveNamed[*contextDefaulter](inventory, "region"); err != nil || resolved.Region != "eu" {
+		t.Errorf("the context given to AddContext should be given to the defaulter: %+v, %v", resolved, err)
+	}
+}
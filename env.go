@@ -0,0 +1,130 @@
+package scaffolder
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/Vorian-Atreides/scaffolder/secret"
+)
+
+const (
+	envTag       = "env"
+	envSeparator = "_"
+)
+
+// LoadEnv fills the exported fields of the configuration tagged with `env` from the
+// environment variables, the values are converted the same way than the default tags.
+// A non empty prefix is joined to the tag value with an underscore.
+//
+//   type Config struct {
+//   	Port    int           `env:"PORT"`
+//   	Timeout time.Duration `env:"TIMEOUT"`
+//   	Hosts   []string      `env:"HOSTS"`
+//   	DB      DBConfig      `env:"DB"`
+//   }
+//
+// With the prefix "HTTP", the fields would be filled from HTTP_PORT, HTTP_TIMEOUT, HTTP_HOSTS
+// and the fields of the nested structure from HTTP_DB_*.
+// The fields whose variable is not defined are left untouched and the references
+// to secrets, such as file:///run/secrets/db_password, are resolved with the secret package.
+func LoadEnv(cfg interface{}, prefix string) error {
+	value := reflect.ValueOf(cfg)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	return loadEnv(value.Elem(), prefix)
+}
+
+func envName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + envSeparator + name
+}
+
+func loadEnv(structValue reflect.Value, prefix string) error {
+	structType := structValue.Type()
+	for y := 0; y < structType.NumField(); y++ {
+		fieldType := structType.Field(y)
+		fieldValue := structValue.Field(y)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		name, ok := fieldType.Tag.Lookup(envTag)
+		switch {
+		case isNested(fieldValue):
+			nestedPrefix := prefix
+			if ok {
+				nestedPrefix = envName(prefix, name)
+			}
+			if err := loadEnv(fieldValue, nestedPrefix); err != nil {
+				return err
+			}
+		case ok:
+			name = envName(prefix, name)
+			raw, found := os.LookupEnv(name)
+			if !found {
+				continue
+			}
+			raw, err := secret.Resolve(raw)
+			if err != nil {
+				return fmt.Errorf("invalid environment variable %s: %w", name, err)
+			}
+			if err := SetString(fieldValue, raw); err != nil {
+				return fmt.Errorf("invalid environment variable %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// EnvPrefix derive an environment variable prefix from a container name,
+// such as HTTP_HANDLER for HTTPHandler or HEALTH_REGISTRY for healthRegistry.
+func EnvPrefix(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for idx, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			builder.WriteString(envSeparator)
+			continue
+		}
+
+		if idx > 0 && unicode.IsUpper(r) {
+			prev := runes[idx-1]
+			nextIsLower := idx+1 < len(runes) && unicode.IsLower(runes[idx+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				builder.WriteString(envSeparator)
+			}
+		}
+		builder.WriteRune(unicode.ToUpper(r))
+	}
+	return builder.String()
+}
+
+// FromEnv fills the configuration from the environment variables prefixed with the
+// container name, as returned by EnvPrefix, and configure the component with it.
+//
+//   inventory.Add(
+//   	&healthcheck.HTTPHandler{},
+//   	scaffolder.WithName("readiness"),
+//   	scaffolder.FromEnv(&HandlerConfig{}), // READINESS_INTERVAL
+//   )
+//
+// The configuration is applied once the component and its container have been initialized,
+// regardless of the position of the option, without assigning the default values again.
+func FromEnv(cfg Configuration) Option {
+	return func(c *container) error {
+		c.hooks = append(c.hooks, func(c *container) error {
+			if err := LoadEnv(cfg, EnvPrefix(c.name)); err != nil {
+				return err
+			}
+			// The component has already been initialized, its defaults must not override its options.
+			return Apply(c.value, cfg.Options()...)
+		})
+		return nil
+	}
+}
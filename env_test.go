@@ -0,0 +1,49 @@
+package scaffolder
+
+import (
+	"testing"
+)
+
+type envPrinter struct {
+	Output string
+	Level  string
+}
+
+func (p *envPrinter) Default() {
+	p.Output = "stderr"
+	p.Level = "info"
+}
+
+func withOutput(output string) OptionT[envPrinter] {
+	return func(p *envPrinter) error {
+		p.Output = output
+		return nil
+	}
+}
+
+type envPrinterConfig struct {
+	Level string `env:"LEVEL"`
+}
+
+func (c *envPrinterConfig) Options() []Option {
+	return []Option{OptionT[envPrinter](func(p *envPrinter) error {
+		p.Level = c.Level
+		return nil
+	})}
+}
+
+func TestFromEnvKeepsOptions(t *testing.T) {
+	t.Setenv("PRINTER_LEVEL", "debug")
+
+	printer := &envPrinter{}
+	inventory := New().Add(printer, WithName("printer"), withOutput("buffer"), FromEnv(&envPrinterConfig{}))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if printer.Output != "buffer" {
+		t.Errorf("the option has been overridden by the defaults: %q", printer.Output)
+	}
+	if printer.Level != "debug" {
+		t.Errorf("the environment has not been applied: %q", printer.Level)
+	}
+}
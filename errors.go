@@ -0,0 +1,140 @@
+package scaffolder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrAmbiguousDependency is returned if several components match a field with the same priority.
+	ErrAmbiguousDependency = errors.New("ambiguous dependency")
+	// ErrUnresolvedDependency is returned if no component match a required field.
+	ErrUnresolvedDependency = errors.New("unresolved dependency")
+	// ErrInvalidTag is returned if the scaffolder tag of a field is malformed.
+	ErrInvalidTag = errors.New("invalid scaffolder tag")
+)
+
+// Errors aggregates several errors into one, it is returned when every error
+// should be reported rather than stopping at the first one.
+type Errors []error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for idx, err := range e {
+		messages[idx] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap let the aggregated errors be inspected with errors.Is and errors.As.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// ErrorOrNil return nil if there is no error, and the Errors otherwise.
+func (e Errors) ErrorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// AmbiguityError describe a field matched by several components with the same priority,
+// the ambiguity can be resolved by naming the preferred component in the field tag.
+// The Container and Field are not set if the error is returned by Resolve.
+type AmbiguityError struct {
+	// Container is the name of the container owning the field.
+	Container string
+	Field     string
+	Type      reflect.Type
+	// Candidates are the names of the matching containers.
+	Candidates []string
+}
+
+func newAmbiguityError(field field, candidates []*container) *AmbiguityError {
+	return &AmbiguityError{
+		Container:  field.owner.name,
+		Field:      field.name,
+		Type:       field.t,
+		Candidates: containerNames(candidates),
+	}
+}
+
+func containerNames(containers []*container) []string {
+	names := make([]string, len(containers))
+	for idx, container := range containers {
+		names[idx] = container.name
+	}
+	return names
+}
+
+func (e *AmbiguityError) Error() string {
+	message := fmt.Sprintf("%s %s, candidates: %s", ErrAmbiguousDependency, e.Type, strings.Join(e.Candidates, ", "))
+	if e.Field == "" {
+		return message
+	}
+	return fmt.Sprintf("%s.%s: %s", e.Container, e.Field, message)
+}
+
+// Unwrap let the error be compared with ErrAmbiguousDependency.
+func (e *AmbiguityError) Unwrap() error {
+	return ErrAmbiguousDependency
+}
+
+// UnresolvedError describe a required field which is not matched by any component.
+// The Container, Component and Field are not set if the error is returned by Resolve.
+type UnresolvedError struct {
+	// Container is the name of the container owning the field.
+	Container string
+	Component reflect.Type
+	Field     string
+	Type      reflect.Type
+	// Name is the container name given to ResolveNamed.
+	Name string
+}
+
+func newUnresolvedError(field field) *UnresolvedError {
+	return &UnresolvedError{
+		Container: field.owner.name,
+		Component: field.owner.t,
+		Field:     field.name,
+		Type:      field.t,
+	}
+}
+
+func (e *UnresolvedError) Error() string {
+	switch {
+	case e.Name != "":
+		return fmt.Sprintf("%s %s named %q", ErrUnresolvedDependency, e.Type, e.Name)
+	case e.Field == "":
+		return fmt.Sprintf("%s %s", ErrUnresolvedDependency, e.Type)
+	}
+	return fmt.Sprintf("%s.%s: %s %s, required by %s",
+		e.Container, e.Field, ErrUnresolvedDependency, e.Type, e.Component)
+}
+
+// Unwrap let the error be compared with ErrUnresolvedDependency.
+func (e *UnresolvedError) Unwrap() error {
+	return ErrUnresolvedDependency
+}
+
+// TagError describe a field whose scaffolder tag is malformed, or does not match its type.
+type TagError struct {
+	// Container is the name of the container owning the field.
+	Container string
+	Field     string
+	Tag       string
+	// Err explains what is wrong with the tag.
+	Err error
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("%s.%s: %s %q: %s", e.Container, e.Field, ErrInvalidTag, e.Tag, e.Err)
+}
+
+// Unwrap let the error be compared with ErrInvalidTag.
+func (e *TagError) Unwrap() error {
+	return ErrInvalidTag
+}
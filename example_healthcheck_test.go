@@ -24,7 +24,7 @@ func (s *SomeComponent) Start(ctx context.Context) error {
 			return nil
 		case <-time.After(time.Duration(i) * time.Second):
 		}
-		a.HealthRegistry.SetStatus("SomeComponent", healthcheck.Status(i))
+		s.HealthRegistry.SetStatus("SomeComponent", healthcheck.Status(i))
 	}
 	return nil
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Vorian-Atreides/scaffolder"
+	"github.com/Vorian-Atreides/scaffolder/config"
+)
+
+type Form struct {
+	FirstName string
+	LastName  string
+	Age       int
+}
+
+func FirstName(value string) scaffolder.OptionT[Form] {
+	return func(f *Form) error {
+		f.FirstName = value
+		return nil
+	}
+}
+
+func LastName(value string) scaffolder.OptionT[Form] {
+	return func(f *Form) error {
+		f.LastName = value
+		return nil
+	}
+}
+
+func Age(value int) scaffolder.OptionT[Form] {
+	return func(f *Form) error {
+		f.Age = value
+		return nil
+	}
+}
+
+type Config struct {
+	FirstName string `json:"first_name" env:"FIRST_NAME"`
+	LastName  string `json:"last_name" env:"LAST_NAME"`
+	Age       int    `json:"age" env:"AGE" default:"42"`
+}
+
+func (c *Config) Options() []scaffolder.Option {
+	return []scaffolder.Option{
+		FirstName(c.FirstName),
+		LastName(c.LastName),
+		Age(c.Age),
+	}
+}
+
+// go run . or APP_FORM_AGE=29 go run .
+func main() {
+	loader, err := config.New(
+		config.WithFile("config.yaml"),
+		config.WithEnvironment("production"),
+		config.WithEnvPrefix("APP"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var form Form
+	if err := loader.Configure(&form, "form", &Config{}); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Form: %v\n", form)
+}
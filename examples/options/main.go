@@ -19,21 +19,21 @@ func (f *Form) Default() {
 	f.Age = 42
 }
 
-func FirstName(value string) scaffolder.Option {
+func FirstName(value string) scaffolder.OptionT[Form] {
 	return func(f *Form) error {
 		f.FirstName = value
 		return nil
 	}
 }
 
-func LastName(value string) scaffolder.Option {
+func LastName(value string) scaffolder.OptionT[Form] {
 	return func(f *Form) error {
 		f.LastName = value
 		return nil
 	}
 }
 
-func Age(value int) scaffolder.Option {
+func Age(value int) scaffolder.OptionT[Form] {
 	return func(f *Form) error {
 		f.Age = value
 		return nil
@@ -43,9 +43,9 @@ func Age(value int) scaffolder.Option {
 const data = `{"first_name": "Erika", "last_name": "Matsukawa", "age": 28}`
 
 type Config struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Age       int    `json:"age"`
+	FirstName string `json:"first_name" env:"FIRST_NAME"`
+	LastName  string `json:"last_name" env:"LAST_NAME"`
+	Age       int    `json:"age" env:"AGE"`
 }
 
 func (c *Config) Options() []scaffolder.Option {
@@ -61,7 +61,7 @@ func main() {
 	_ = scaffolder.Init(&form)
 	fmt.Printf("Form: %v\n", form)
 
-	_ = scaffolder.Init(
+	_ = scaffolder.InitT(
 		&form,
 		FirstName("Gaston"),
 		LastName("Siffert"),
@@ -73,4 +73,9 @@ func main() {
 	_ = json.Unmarshal([]byte(data), &cfg)
 	_ = scaffolder.Configure(&form, &cfg)
 	fmt.Printf("Form: %v\n", form)
+
+	// FORM_FIRST_NAME=Erika FORM_AGE=28 go run ./examples/options
+	_ = scaffolder.LoadEnv(&cfg, "FORM")
+	_ = scaffolder.Configure(&form, &cfg)
+	fmt.Printf("Form: %v\n", form)
 }
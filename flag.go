@@ -0,0 +1,170 @@
+package scaffolder
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	flagTag       = "flag"
+	usageTag      = "usage"
+	flagSeparator = "."
+)
+
+// ErrDuplicateFlag is returned by BindFlags if a flag is already defined in the FlagSet,
+// such as two configurations bound with the same prefix.
+var ErrDuplicateFlag = errors.New("flag redefined")
+
+// flagValue implements the flag.Value interface over a field of a configuration.
+type flagValue struct {
+	value reflect.Value
+}
+
+func (f flagValue) String() string {
+	if !f.value.IsValid() {
+		return ""
+	}
+	return formatValue(f.value)
+}
+
+func (f flagValue) Set(raw string) error {
+	return SetString(f.value, raw)
+}
+
+// IsBoolFlag let the boolean flags be set without value: -verbose.
+func (f flagValue) IsBoolFlag() bool {
+	return f.value.IsValid() && f.value.Kind() == reflect.Bool
+}
+
+// formatValue is the reverse of SetString.
+func formatValue(value reflect.Value) string {
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(text)
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return ""
+		}
+		return formatValue(value.Elem())
+	case reflect.Slice:
+		items := make([]string, value.Len())
+		for idx := range items {
+			items[idx] = formatValue(value.Index(idx))
+		}
+		return strings.Join(items, itemSeparator)
+	case reflect.Map:
+		items := make([]string, 0, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			items = append(items, formatValue(iter.Key())+keyValueSeparator+formatValue(iter.Value()))
+		}
+		sort.Strings(items)
+		return strings.Join(items, itemSeparator)
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// isConvertible report if SetString is able to convert a string into the type.
+func isConvertible(t reflect.Type) bool {
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Ptr:
+		return isConvertible(t.Elem())
+	case reflect.Map:
+		return isConvertible(t.Key()) && isConvertible(t.Elem())
+	case reflect.Struct, reflect.Array, reflect.Chan, reflect.Func,
+		reflect.Interface, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return false
+	}
+	return true
+}
+
+func flagName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + flagSeparator + name
+}
+
+// BindFlags register a flag in the FlagSet for every exported field of the configuration,
+// the parsed values are converted the same way than the default tags and written into the configuration.
+//
+// The flag is named after the `flag` tag or the lower-cased field name, the nested structures
+// and the non empty prefix are joined with a dot. The usage text is read from the `usage` tag
+// and the fields tagged with `flag:"-"` are ignored.
+//
+//   type Config struct {
+//   	Port int `flag:"port" usage:"the listening port"`
+//   	DB   DBConfig         // -http.db.host, -http.db.user...
+//   }
+//
+//   scaffolder.BindFlags(flag.CommandLine, "http", &cfg) // -http.port
+//
+// The flags already defined in the FlagSet are not redefined, an ErrDuplicateFlag is returned
+// and the flags of the configuration bound until then are kept.
+func BindFlags(fs *flag.FlagSet, prefix string, cfg interface{}) error {
+	value := reflect.ValueOf(cfg)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	return bindFlags(fs, prefix, value.Elem())
+}
+
+func bindFlags(fs *flag.FlagSet, prefix string, structValue reflect.Value) error {
+	structType := structValue.Type()
+	for y := 0; y < structType.NumField(); y++ {
+		fieldType := structType.Field(y)
+		fieldValue := structValue.Field(y)
+		name := fieldType.Tag.Get(flagTag)
+		if !fieldValue.CanSet() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(fieldType.Name)
+		}
+
+		switch {
+		case isNested(fieldValue):
+			if err := bindFlags(fs, flagName(prefix, name), fieldValue); err != nil {
+				return err
+			}
+		case isConvertible(fieldValue.Type()):
+			name = flagName(prefix, name)
+			if fs.Lookup(name) != nil {
+				return fmt.Errorf("%w: -%s", ErrDuplicateFlag, name)
+			}
+			fs.Var(flagValue{value: fieldValue}, name, fieldType.Tag.Get(usageTag))
+		}
+	}
+	return nil
+}
+
+// ConfigureFlags bind the configuration to a new FlagSet, parse the arguments
+// and configure the target with the resulting configuration.
+//
+//   err := scaffolder.ConfigureFlags(&form, &Config{}, os.Args[1:])
+func ConfigureFlags(target Component, cfg Configuration, args []string) error {
+	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	if err := BindFlags(fs, "", cfg); err != nil {
+		return err
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return Configure(target, cfg)
+}
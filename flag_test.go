@@ -0,0 +1,77 @@
+package scaffolder
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type flagDatabaseConfig struct {
+	Host  string
+	Hosts []string `flag:"replicas"`
+}
+
+type flagConfig struct {
+	Port     int           `flag:"port" usage:"the listening port"`
+	Interval time.Duration `usage:"the interval between two checks"`
+	Ignored  string        `flag:"-"`
+	Database flagDatabaseConfig
+	hidden   string
+}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func TestBindFlags(t *testing.T) {
+	fs := newFlagSet()
+	cfg := &flagConfig{Port: 80}
+	if err := BindFlags(fs, "http", cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, f.Name)
+	})
+	expected := []string{"http.database.host", "http.database.replicas", "http.interval", "http.port"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected the flags %v, got: %v", expected, names)
+	}
+	if port := fs.Lookup("http.port"); port.Usage != "the listening port" || port.DefValue != "80" {
+		t.Errorf("unexpected port flag: %+v", port)
+	}
+
+	err := fs.Parse([]string{"-http.port=8080", "-http.interval=2s", "-http.database.replicas=a,b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Port != 8080 || cfg.Interval != 2*time.Second || !reflect.DeepEqual(cfg.Database.Hosts, []string{"a", "b"}) {
+		t.Errorf("the flags have not been written into the configuration: %+v", cfg)
+	}
+}
+
+func TestBindFlagsDuplicate(t *testing.T) {
+	fs := newFlagSet()
+	if err := BindFlags(fs, "http", &flagConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := BindFlags(fs, "http", &flagConfig{}); !errors.Is(err, ErrDuplicateFlag) {
+		t.Errorf("expected %v, got: %v", ErrDuplicateFlag, err)
+	}
+}
+
+func TestConfigureFlags(t *testing.T) {
+	printer := &envPrinter{}
+	if err := ConfigureFlags(printer, &envPrinterConfig{}, []string{"-level=debug"}); err != nil {
+		t.Fatal(err)
+	}
+	if printer.Level != "debug" || printer.Output != "stderr" {
+		t.Errorf("unexpected printer: %+v", printer)
+	}
+}
@@ -0,0 +1,236 @@
+package scaffolder
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrDependencyCycle is returned if the components depend on each other in a cycle.
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// Graph describe the dependencies between the containers, as linked by Compile.
+type Graph struct {
+	containers   []*container
+	dependencies map[*container][]*container
+	edges        map[edge]bool
+}
+
+type edge struct {
+	from, to *container
+}
+
+func newGraph(containers []*container) *Graph {
+	return &Graph{
+		containers:   containers,
+		dependencies: make(map[*container][]*container, len(containers)),
+		edges:        make(map[edge]bool),
+	}
+}
+
+// reachable return the roots and every container they depend on, directly or not.
+func (g *Graph) reachable(roots []*container) map[*container]bool {
+	visited := make(map[*container]bool, len(g.containers))
+	for len(roots) > 0 {
+		container := roots[len(roots)-1]
+		roots = roots[:len(roots)-1]
+		if visited[container] {
+			continue
+		}
+		visited[container] = true
+		roots = append(roots, g.dependencies[container]...)
+	}
+	return visited
+}
+
+// subgraph return the graph restricted to the given containers.
+func (g *Graph) subgraph(keep map[*container]bool) *Graph {
+	var containers []*container
+	for _, container := range g.containers {
+		if keep[container] {
+			containers = append(containers, container)
+		}
+	}
+
+	subgraph := newGraph(containers)
+	for _, container := range containers {
+		for _, dependency := range g.dependencies[container] {
+			if keep[dependency] {
+				subgraph.addEdge(container, dependency)
+			}
+		}
+	}
+	return subgraph
+}
+
+func (g *Graph) addEdge(from, to *container) {
+	if g.edges[edge{from, to}] {
+		return
+	}
+	g.edges[edge{from, to}] = true
+	g.dependencies[from] = append(g.dependencies[from], to)
+}
+
+func (g *Graph) lookup(c Container) *container {
+	component := c.Component()
+	// Only the pointers are compared, the other components might not be comparable.
+	byAddress := component != nil && reflect.ValueOf(component).Kind() == reflect.Ptr
+	for _, container := range g.containers {
+		if container == c || (byAddress && container.value == component) {
+			return container
+		}
+	}
+	return nil
+}
+
+// Dependencies return the containers assigned to the fields of the given container.
+func (g *Graph) Dependencies(c Container) []Container {
+	container := g.lookup(c)
+	if container == nil {
+		return nil
+	}
+
+	dependencies := make([]Container, len(g.dependencies[container]))
+	for idx, dependency := range g.dependencies[container] {
+		dependencies[idx] = dependency
+	}
+	return dependencies
+}
+
+// Dependents return the containers which have been assigned the given container.
+func (g *Graph) Dependents(c Container) []Container {
+	target := g.lookup(c)
+	if target == nil {
+		return nil
+	}
+
+	var dependents []Container
+	for _, container := range g.containers {
+		for _, dependency := range g.dependencies[container] {
+			if dependency == target {
+				dependents = append(dependents, container)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// Order return the containers sorted topologically, every container comes after its dependencies
+// and the independent containers keep the order in which they were added.
+// A CycleError is returned if the containers depend on each other in a cycle.
+//
+// The containers are ordered by successive passes in the order they were added,
+// a container being ordered by the first pass reaching it once its dependencies are.
+func (g *Graph) Order() ([]Container, error) {
+	positions := make(map[*container]int, len(g.containers))
+	remaining := make(map[*container]int, len(g.containers))
+	dependents := make(map[*container][]*container, len(g.containers))
+	var current, next positionHeap
+	for position, container := range g.containers {
+		positions[container] = position
+		remaining[container] = len(g.dependencies[container])
+		for _, dependency := range g.dependencies[container] {
+			dependents[dependency] = append(dependents[dependency], container)
+		}
+		if remaining[container] == 0 {
+			current = append(current, position)
+		}
+	}
+
+	done := make(map[*container]bool, len(g.containers))
+	order := make([]Container, 0, len(g.containers))
+	for current.Len() > 0 {
+		// The current pass is at this position, the containers which become ready
+		// before it have to wait for the next pass.
+		position := heap.Pop(&current).(int)
+		container := g.containers[position]
+		done[container] = true
+		order = append(order, container)
+
+		for _, dependent := range dependents[container] {
+			remaining[dependent]--
+			if remaining[dependent] > 0 {
+				continue
+			}
+			if positions[dependent] > position {
+				heap.Push(&current, positions[dependent])
+			} else {
+				heap.Push(&next, positions[dependent])
+			}
+		}
+		if current.Len() == 0 {
+			current, next = next, current
+		}
+	}
+
+	if len(order) < len(g.containers) {
+		return nil, g.cycle(done)
+	}
+	return order, nil
+}
+
+// positionHeap implements the heap.Interface over the positions of the containers.
+type positionHeap []int
+
+func (h positionHeap) Len() int            { return len(h) }
+func (h positionHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h positionHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *positionHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *positionHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// cycle walk through the remaining containers until it comes back to one of them,
+// every remaining container has at least one remaining dependency.
+func (g *Graph) cycle(done map[*container]bool) error {
+	var path []*container
+	visited := map[*container]int{}
+	for _, container := range g.containers {
+		if done[container] {
+			continue
+		}
+
+		for current := container; ; {
+			if idx, ok := visited[current]; ok {
+				return newCycleError(append(path[idx:], current))
+			}
+			visited[current] = len(path)
+			path = append(path, current)
+			for _, dependency := range g.dependencies[current] {
+				if !done[dependency] {
+					current = dependency
+					break
+				}
+			}
+		}
+	}
+	return ErrDependencyCycle
+}
+
+// CycleError describe the path of a dependency cycle, the first and last containers are the same.
+type CycleError struct {
+	Path []string
+}
+
+func newCycleError(path []*container) *CycleError {
+	names := make([]string, len(path))
+	for idx, container := range path {
+		names[idx] = container.name
+	}
+	return &CycleError{Path: names}
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrDependencyCycle, strings.Join(e.Path, " -> "))
+}
+
+// Unwrap let the error be compared with ErrDependencyCycle.
+func (e *CycleError) Unwrap() error {
+	return ErrDependencyCycle
+}
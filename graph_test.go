@@ -0,0 +1,102 @@
+package scaffolder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type graphServer struct {
+	Handler *graphHandler
+}
+
+type graphHandler struct {
+	Repository *graphRepository
+}
+
+type graphRepository struct {
+	Database *graphDatabase
+}
+
+type graphDatabase struct {
+	// Server closes the cycle once it is resolved.
+	Server *graphServer `scaffolder:",optional"`
+}
+
+type graphClock struct{}
+
+func containerNamesOf(containers []Container) []string {
+	names := make([]string, len(containers))
+	for idx, container := range containers {
+		names[idx] = container.Name()
+	}
+	return names
+}
+
+func TestGraphOrder(t *testing.T) {
+	// The server assigned manually to the database is not part of the inventory,
+	// the database does not depend on it.
+	inventory := New().
+		Add(&graphServer{}, WithName("server")).
+		Add(&graphClock{}).
+		Add(&graphHandler{}).
+		Add(&graphRepository{}).
+		Add(&graphDatabase{Server: &graphServer{}})
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	order, err := inventory.Graph().Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"graphClock", "graphDatabase", "graphRepository", "graphHandler", "server"}
+	if names := containerNamesOf(order); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected the order %v, got: %v", expected, names)
+	}
+
+	containers := inventory.Containers()
+	if names := containerNamesOf(inventory.Graph().Dependencies(containers[2])); !reflect.DeepEqual(names, []string{"graphRepository"}) {
+		t.Errorf("unexpected dependencies of the handler: %v", names)
+	}
+	if names := containerNamesOf(inventory.Graph().Dependents(containers[2])); !reflect.DeepEqual(names, []string{"server"}) {
+		t.Errorf("unexpected dependents of the handler: %v", names)
+	}
+}
+
+func TestGraphCycle(t *testing.T) {
+	err := New().
+		Add(&graphClock{}).
+		Add(&graphServer{}).
+		Add(&graphHandler{}).
+		Add(&graphRepository{}).
+		Add(&graphDatabase{}).
+		Compile()
+
+	var cycle *CycleError
+	if !errors.As(err, &cycle) || !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("expected a CycleError, got: %v", err)
+	}
+	expected := []string{"graphServer", "graphHandler", "graphRepository", "graphDatabase", "graphServer"}
+	if !reflect.DeepEqual(cycle.Path, expected) {
+		t.Errorf("expected the cycle %v, got: %v", expected, cycle.Path)
+	}
+}
+
+func TestGraphNotComparable(t *testing.T) {
+	inventory := New().
+		Provide(func() greeter { return greeterFunc(func() string { return "hello" }) }, WithName("english")).
+		Provide(func() greeter { return greeterFunc(func() string { return "bonjour" }) }, WithName("french")).
+		Add(&greeterRegistry{}, WithName("registry"))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	containers := inventory.Containers()
+	if names := containerNamesOf(inventory.Graph().Dependents(containers[1])); !reflect.DeepEqual(names, []string{"registry"}) {
+		t.Errorf("unexpected dependents of the provided function: %v", names)
+	}
+	if names := containerNamesOf(inventory.Graph().Dependencies(containers[2])); !reflect.DeepEqual(names, []string{"english", "french"}) {
+		t.Errorf("unexpected dependencies of the registry: %v", names)
+	}
+}
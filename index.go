@@ -0,0 +1,80 @@
+package scaffolder
+
+import (
+	"reflect"
+	"sync"
+)
+
+// index speeds up the resolution of the fields by grouping the containers of a scope
+// by name and by type, the containers keep the order in which they were added.
+type index struct {
+	containers []*container
+	byName     map[string][]*container
+	byType     map[reflect.Type][]*container
+	// assignable caches the containers assignable to a type,
+	// such as the ones implementing an interface.
+	// The Resolve functions share the indexes and might be called concurrently.
+	assignable map[reflect.Type][]*container
+	mu         sync.Mutex
+}
+
+func newIndex(containers []*container) *index {
+	index := &index{
+		containers: containers,
+		byName:     make(map[string][]*container, len(containers)),
+		byType:     make(map[reflect.Type][]*container, len(containers)),
+		assignable: make(map[reflect.Type][]*container),
+	}
+	for _, container := range containers {
+		index.byName[container.name] = append(index.byName[container.name], container)
+		index.byType[container.t] = append(index.byType[container.t], container)
+	}
+	return index
+}
+
+func newIndexes(scopes [][]*container) []*index {
+	indexes := make([]*index, len(scopes))
+	for idx, scope := range scopes {
+		indexes[idx] = newIndex(scope)
+	}
+	return indexes
+}
+
+// assignableTo return the containers assignable to the given type,
+// the inventory is only scanned once per type.
+func (i *index) assignableTo(t reflect.Type) []*container {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if matches, ok := i.assignable[t]; ok {
+		return matches
+	}
+
+	var matches []*container
+	for _, container := range i.containers {
+		if container.t.AssignableTo(t) {
+			matches = append(matches, container)
+		}
+	}
+	i.assignable[t] = matches
+	return matches
+}
+
+// lookups narrow down the containers to the ones which might satisfy the condition
+// with the same priority, they must return them in the order they were added.
+var lookups = []func(field field, index *index) []*container{
+	func(field field, index *index) []*container {
+		return index.byName[field.tag]
+	},
+	func(field field, index *index) []*container {
+		return index.byName[field.name]
+	},
+	func(field field, index *index) []*container {
+		return index.byType[field.t]
+	},
+	func(field field, index *index) []*container {
+		if field.t.Kind() != reflect.Interface {
+			return nil
+		}
+		return index.assignableTo(field.t)
+	},
+}
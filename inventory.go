@@ -1,13 +1,18 @@
 package scaffolder
 
 import (
+	"context"
 	"errors"
 	"reflect"
+	"strings"
+	"sync"
 )
 
 const (
-	tag = "scaffolder"
-	all = "containers"
+	tag         = "scaffolder"
+	all         = "containers"
+	optionalTag = "optional"
+	ignoredTag  = "-"
 )
 
 var (
@@ -15,11 +20,77 @@ var (
 	ErrInvalidComponent = errors.New("component is neither a pointer nor an interface")
 )
 
+// binding record the containers resolved for a field until they can be assigned.
+type binding struct {
+	field   field
+	matches []*container
+}
+
+// fieldError is an error reported for one of the fields of the owner.
+type fieldError struct {
+	owner *container
+	err   error
+}
+
 type field struct {
+	owner *container
 	value reflect.Value
 	t     reflect.Type
 	tag   string
 	name  string
+
+	optional bool
+	// lazy fields are functions returning the dependency, they do not constrain the order
+	// of the containers.
+	lazy   bool
+	labels map[string]string
+}
+
+// pointsTo report if the field holds a pointer which might be one of the components.
+func (f field) pointsTo() bool {
+	value := f.value
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	return value.Kind() == reflect.Ptr
+}
+
+// required report if the field must be resolved by Compile.
+func (f field) required() bool {
+	kind := f.t.Kind()
+	return !f.optional && (kind == reflect.Ptr || kind == reflect.Interface)
+}
+
+var containerInterface = reflect.TypeOf((*Container)(nil)).Elem()
+
+// isContainers report if the field asks for every container of the inventory.
+func (f field) isContainers() bool {
+	return f.t.Kind() == reflect.Slice && f.tag == all && containerInterface.AssignableTo(f.t.Elem())
+}
+
+// isCollection report if the field is a slice or a map to be filled with several components.
+func (f field) isCollection() bool {
+	switch f.t.Kind() {
+	case reflect.Slice:
+		if f.isContainers() {
+			return true
+		}
+	case reflect.Map:
+		if f.t.Key().Kind() != reflect.String {
+			return false
+		}
+	default:
+		return false
+	}
+	elem := f.t.Elem()
+	switch elem.Kind() {
+	case reflect.Ptr:
+		return true
+	case reflect.Interface:
+		// Any component would match an empty interface.
+		return elem.NumMethod() > 0
+	}
+	return false
 }
 
 // Inventory define a registry of component where any component can resolve its dependencies.
@@ -31,51 +102,146 @@ type field struct {
 //   3. The component type match the field type.
 //   4. Component implements the field interface.
 //
-// A valid assignable field must be a public and can be either a pointer, an interface or a slice.
-// At the moment the slice is an experiments to build higher level components over the whole
-// set of components.
+// If several components match a field with the same priority, the dependency is ambiguous
+// and Compile return an AmbiguityError. Naming the preferred component in the field tag
+// resolves the ambiguity. A component is never assigned to its own fields.
+//
+// A valid assignable field must be a public and can be either a pointer, an interface,
+// a slice or a map.
+//
+// The slices and maps are collections used to build higher level components over
+// a set of components, such as a registry of every health checker:
+//
+//   type Registry struct {
+//   	// Every component implementing Checker, in the order they were added.
+//   	Checkers []Checker
+//   	// Every component implementing Router, keyed by their container name.
+//   	Routes map[string]Router
+//   	// Every container of the inventory.
+//   	Containers []scaffolder.Container `scaffolder:"containers"`
+//   }
+//
+// The elements of a collection must be pointers or non empty interfaces and a component is never
+// part of its own collections, the slices and maps of interface{}, such as the meta-data of
+// a component, are left untouched. The collections are optional and left nil if nothing matches,
+// a map is ambiguous if several of its components share the same container name.
+//
+// The pointer and interface fields are required, Compile return an UnresolvedError for every
+// one of them which could not be assigned. They can be marked as optional in their tag,
+// alone or after the name of the component: `scaffolder:",optional"`, `scaffolder:"db,optional"`.
+// The fields tagged with `scaffolder:"-"` are ignored.
+//
+// The tag is a list of elements separated by commas, the name being either the first element
+// or given with the name key, which is required if the name is one of the flags:
+//   - name=primary: the name of the component, see WithName.
+//   - optional: the field is not required.
+//   - lazy: the field is a function returning the dependency, func() *DB, which is not
+//     required to be started before the component and might therefore depend on it.
+//   - label=region:eu: only the components with this label are assigned, see WithLabel.
+//     The label element can be repeated to require several labels.
+//
+//   type Service struct {
+//   	DB    func() *sql.DB `scaffolder:"name=primary,lazy,label=region:eu"`
+//   	Cache Cache          `scaffolder:"cache,optional"`
+//   }
+//
+// The malformed tags are reported by Compile as TagError.
 type Inventory struct {
+	parent     *Inventory
 	fields     []field
 	containers []*container
-	all        []Container
+	graph      *Graph
+	// replacements are swapped with the components they replace by Compile.
+	replacements []*container
+	// source is the last ConfigurationSource given to Configure.
+	source ConfigurationSource
+	// indexes cache the materialized components for the Resolve functions,
+	// they are rebuilt once the inventory or one of its ancestors has changed.
+	indexes  []*index
+	revision int
+	indexed  int
+	mu       sync.Mutex
 
-	addErr error
+	strict bool
+	errs   Errors
 }
 
-// New build a new Inventory.
-func New() *Inventory {
-	return &Inventory{}
+// New build a new Inventory and customize it with the given Options.
+func New(opts ...Option) *Inventory {
+	inventory := &Inventory{}
+	if err := Init(inventory, opts...); err != nil {
+		inventory.errs = append(inventory.errs, err)
+	}
+	return inventory
+}
+
+// WithStrictMode makes the Inventory report, through the Compile method,
+// the options given to Add which applied neither to the component nor its container.
+func WithStrictMode() Option {
+	return func(i *Inventory) error {
+		i.strict = true
+		return nil
+	}
 }
 
 func (i *Inventory) isSettableType(kind reflect.Kind) bool {
-	return kind == reflect.Slice || kind == reflect.Ptr || kind == reflect.Interface
+	return kind == reflect.Slice || kind == reflect.Map || kind == reflect.Ptr || kind == reflect.Interface
 }
 
-func (i *Inventory) extractFields(container *container) []field {
+// extractFields return the fields to be resolved by Compile and the malformed tags as TagError.
+func (i *Inventory) extractFields(container *container) ([]field, Errors) {
 	componentType := container.t.Elem()
 	if componentType.Kind() != reflect.Struct {
-		return nil
+		return nil, nil
 	}
 
 	var fields []field
+	var errs Errors
 	structType := componentType
 	structValue := reflect.ValueOf(container.Component()).Elem()
 	for y := 0; y < structType.NumField(); y++ {
 		fieldType := structType.Field(y)
 		fieldValue := structValue.Field(y)
-		if !fieldValue.CanSet() || !i.isSettableType(fieldValue.Type().Kind()) {
+		tagValue := fieldType.Tag.Get(tag)
+		if !fieldValue.CanSet() || tagValue == ignoredTag {
+			continue
+		}
+
+		options, err := parseTag(tagValue)
+		if err == nil && options.lazy && !isLazyType(fieldValue.Type()) {
+			err = errors.New("a lazy field must be a function without argument returning its dependency")
+		}
+		if err != nil {
+			errs = append(errs, &TagError{Container: container.name, Field: fieldType.Name, Tag: tagValue, Err: err})
+			continue
+		}
+
+		t := fieldValue.Type()
+		if options.lazy {
+			t = t.Out(0)
+		}
+		if !i.isSettableType(t.Kind()) {
 			continue
 		}
 
 		f := field{
-			value: fieldValue,
-			t:     fieldValue.Type(),
-			tag:   fieldType.Tag.Get(tag),
-			name:  fieldType.Name,
+			owner:    container,
+			value:    fieldValue,
+			t:        t,
+			tag:      options.name,
+			name:     fieldType.Name,
+			optional: options.optional,
+			lazy:     options.lazy,
+			labels:   options.labels,
 		}
 		fields = append(fields, f)
 	}
-	return fields
+	return fields, errs
+}
+
+// isLazyType report if the type is a function returning a dependency: func() T.
+func isLazyType(t reflect.Type) bool {
+	return t.Kind() == reflect.Func && t.NumIn() == 0 && t.NumOut() == 1
 }
 
 // Add a component to the inventory, it will take care of calling Init with the
@@ -83,40 +249,112 @@ func (i *Inventory) extractFields(container *container) []field {
 // You could use the WithName Option if you intend to assign the component
 // to matching structure tags.
 //
-// Any error while initializing a component will be returned in the Compile method.
+// Any error while initializing a component will be returned in the Compile method,
+// every failure is collected and returned together as Errors.
 func (i *Inventory) Add(component Component, opts ...Option) *Inventory {
-	if i.addErr != nil {
+	return i.AddContext(context.Background(), component, opts...)
+}
+
+// AddContext behave like Add and give the context to the components
+// implementing the ContextDefaulter interface.
+func (i *Inventory) AddContext(ctx context.Context, component Component, opts ...Option) *Inventory {
+	return i.register(ctx, component, opts, false)
+}
+
+// AddLazy register a component which is only initialized if another component needs it,
+// through one of its fields or the parameters of its constructor.
+// It is intended for the optional components, such as database or cache clients,
+// which are only used by some of the applications sharing the same wiring.
+//
+// Until it is materialized by Compile, the component is neither returned by Containers
+// nor configured, validated or part of the dependency Graph.
+// Once materialized, it is configured from the source last given to Configure.
+func (i *Inventory) AddLazy(component Component, opts ...Option) *Inventory {
+	return i.AddLazyContext(context.Background(), component, opts...)
+}
+
+// AddLazyContext behave like AddLazy and give the context to the components
+// implementing the ContextDefaulter interface.
+func (i *Inventory) AddLazyContext(ctx context.Context, component Component, opts ...Option) *Inventory {
+	return i.register(ctx, component, opts, true)
+}
+
+// register add the component and its fields to the inventory, or record the failure.
+func (i *Inventory) register(ctx context.Context, component Component, opts []Option, lazy bool) *Inventory {
+	container, err := i.add(ctx, component, opts, lazy)
+	if err != nil {
+		i.errs = append(i.errs, err)
 		return i
 	}
 
+	i.containers = append(i.containers, container)
+	i.revision++
+	fields, errs := i.extractFields(container)
+	i.fields = append(i.fields, fields...)
+	i.errs = append(i.errs, errs...)
+	return i
+}
+
+func (i *Inventory) add(ctx context.Context, component Component, opts []Option, lazy bool) (*container, error) {
 	cType := reflect.TypeOf(component)
-	if cType.Kind() != reflect.Ptr && cType.Kind() != reflect.Interface {
-		i.addErr = ErrInvalidComponent
-		return i
-	}
-	if err := Init(component, opts...); err != nil {
-		i.addErr = err
-		return i
+	if cType == nil || (cType.Kind() != reflect.Ptr && cType.Kind() != reflect.Interface) {
+		return nil, &InitError{Component: cType, Index: -1, Err: ErrInvalidComponent}
 	}
 
+	// The container is initialized first, so the errors can be reported with its name.
+	// The same options are shared between the component and its container,
+	// an option is only unmatched if it applied to neither of them.
+	applied := make([]bool, len(opts))
 	container := &container{value: component, t: cType}
-	if err := Init(container, opts...); err != nil {
-		i.addErr = err
-		return i
+	if err := setDefaults(ctx, container); err != nil {
+		return nil, withComponent(err, cType, container.name)
+	}
+	if err := apply(container, opts, applied); err != nil {
+		return nil, withComponent(err, cType, container.name)
 	}
 
-	i.containers = append(i.containers, container)
-	i.all = append(i.all, container)
-	i.fields = append(i.fields, i.extractFields(container)...)
-	return i
+	materialize := func() error {
+		if err := setDefaults(ctx, component); err != nil {
+			return withComponent(err, cType, container.name)
+		}
+		return i.initialize(container, opts, applied)
+	}
+	if lazy {
+		container.lazy = true
+		container.materialize = materialize
+		return container, nil
+	}
+	if err := materialize(); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
+
+// initialize apply the options to the component of the container and run its hooks,
+// the container must have been initialized with the same options beforehand.
+func (i *Inventory) initialize(container *container, opts []Option, applied []bool) error {
+	if err := apply(container.value, opts, applied); err != nil {
+		return withComponent(err, container.t, container.name)
+	}
+	if i.strict {
+		if err := unmatchedOptions(container.t, opts, applied); err != nil {
+			return withComponent(err, container.t, container.name)
+		}
+	}
+	for _, hook := range container.hooks {
+		if err := hook(container); err != nil {
+			return withComponent(err, container.t, container.name)
+		}
+	}
+	return nil
 }
 
 var conditions = []func(field field, container *container) bool{
 	func(field field, container *container) bool {
-		return field.tag == container.name
+		return field.tag != "" && field.tag == container.name
 	},
 	func(field field, container *container) bool {
-		return field.t == container.t && field.name == container.name
+		return field.name != "" && field.t == container.t && field.name == container.name
 	},
 	func(field field, container *container) bool {
 		return field.t == container.t
@@ -127,28 +365,435 @@ var conditions = []func(field field, container *container) bool{
 	},
 }
 
-// Compile will attempt to link the components together.
+// candidates return the containers matching the field with the highest priority.
+// The local containers come first for each priority, see Child.
+func (i *Inventory) candidates(field field, indexes []*index) []*container {
+	for priority, condition := range conditions {
+		for _, index := range indexes {
+			var matches []*container
+			for _, container := range lookups[priority](field, index) {
+				if container != field.owner &&
+					container.t.AssignableTo(field.t) &&
+					container.hasLabels(field.labels) &&
+					condition(field, container) {
+					matches = append(matches, container)
+				}
+			}
+			if len(matches) > 0 {
+				return matches
+			}
+		}
+	}
+	return nil
+}
+
+// resolve return the containers to be assigned to the field, among the indexed scopes.
+func (i *Inventory) resolve(field field, indexes []*index) ([]*container, error) {
+	if !field.isCollection() {
+		candidates := i.candidates(field, indexes)
+		switch {
+		case len(candidates) == 0 && field.required():
+			return nil, newUnresolvedError(field)
+		case len(candidates) > 1:
+			return nil, newAmbiguityError(field, candidates)
+		}
+		return candidates, nil
+	}
+	if field.isContainers() {
+		var containers []*container
+		for idx := len(indexes) - 1; idx >= 0; idx-- {
+			for _, container := range indexes[idx].containers {
+				if container.hasLabels(field.labels) {
+					containers = append(containers, container)
+				}
+			}
+		}
+		return containers, nil
+	}
+
+	// The collections hold every matching component, in the order they were added to the inventory.
+	var matches []*container
+	for idx := len(indexes) - 1; idx >= 0; idx-- {
+		for _, container := range indexes[idx].assignableTo(field.t.Elem()) {
+			if container != field.owner && container.hasLabels(field.labels) {
+				matches = append(matches, container)
+			}
+		}
+	}
+	if field.t.Kind() == reflect.Map {
+		byName := make(map[string][]*container, len(matches))
+		for _, match := range matches {
+			byName[match.name] = append(byName[match.name], match)
+		}
+		for _, match := range matches {
+			if duplicates := byName[match.name]; len(duplicates) > 1 {
+				return nil, newAmbiguityError(field, duplicates)
+			}
+		}
+	}
+	return matches, nil
+}
+
+// bind assign the resolved containers to the field, their components must have been built.
+func (i *Inventory) bind(field field, matches []*container) {
+	// The field might have been assigned while materializing its lazy component.
+	if len(matches) == 0 || !field.value.IsNil() {
+		return
+	}
+
+	if field.lazy {
+		// The components are only assigned once the function is called,
+		// the provided ones might not have been built yet.
+		resolved := field
+		resolved.lazy = false
+		field.value.Set(reflect.MakeFunc(field.value.Type(), func([]reflect.Value) []reflect.Value {
+			target := resolved
+			target.value = reflect.New(target.t).Elem()
+			i.bind(target, matches)
+			return []reflect.Value{target.value}
+		}))
+		return
+	}
+
+	switch {
+	case field.isContainers():
+		values := reflect.MakeSlice(field.t, 0, len(matches))
+		for _, match := range matches {
+			if !match.lazy {
+				values = reflect.Append(values, reflect.ValueOf(match))
+			}
+		}
+		field.value.Set(values)
+	case !field.isCollection():
+		if matches[0].value != nil {
+			field.value.Set(reflect.ValueOf(matches[0].value))
+		}
+	case field.t.Kind() == reflect.Slice:
+		values := reflect.MakeSlice(field.t, 0, len(matches))
+		for _, match := range matches {
+			if match.value != nil {
+				values = reflect.Append(values, reflect.ValueOf(match.value))
+			}
+		}
+		field.value.Set(values)
+	case field.t.Kind() == reflect.Map:
+		values := reflect.MakeMapWithSize(field.t, len(matches))
+		for _, match := range matches {
+			if match.value != nil {
+				values.SetMapIndex(reflect.ValueOf(match.name).Convert(field.t.Key()), reflect.ValueOf(match.value))
+			}
+		}
+		field.value.Set(values)
+	}
+}
+
+// Compile will attempt to link the components together and build their dependency Graph,
+// every ambiguous or unresolved dependency and every dependency cycle
+// is reported and returned together as Errors.
+//
+// The provided components are built by Compile, following the order of the dependency Graph.
 func (i *Inventory) Compile() error {
-	if i.addErr != nil {
-		return i.addErr
+	if err := i.errs.ErrorOrNil(); err != nil {
+		return err
+	}
+	// The replacements and the materialized components change the resolvable ones.
+	i.revision++
+	if err := i.replace(); err != nil {
+		return err
 	}
 
-	// This algorithm has a time complexity of O(N)3,
-	// it assume that no inventory will ever have hundreds of components.
-Next:
+	byValue := make(map[Component]*container, len(i.containers))
+	local := make(map[*container]bool, len(i.containers))
+	for _, container := range i.containers {
+		local[container] = true
+		// The components are only known by their address, the other values might not be hashable,
+		// such as a provided http.HandlerFunc.
+		if container.value != nil && reflect.ValueOf(container.value).Kind() == reflect.Ptr {
+			byValue[container.value] = container
+		}
+	}
+
+	// The containers are indexed by name and by type, so resolving a field does not
+	// scan the whole inventory, see lookups.
+	var errs []fieldError
+	indexes := newIndexes(i.scopes())
+	bindings := make(map[*container][]binding)
+	graph := newGraph(i.containers)
+	// needs also holds the dependencies of the lazy fields, which do not constrain the order.
+	needs := newGraph(i.containers)
 	for _, field := range i.fields {
 		if !field.value.IsNil() {
+			// The field has been assigned manually, it might still be a known component.
+			if field.pointsTo() {
+				if dependency, ok := byValue[field.value.Interface()]; ok && dependency != field.owner {
+					graph.addEdge(field.owner, dependency)
+					needs.addEdge(field.owner, dependency)
+				}
+			}
 			continue
 		}
 
-		for _, condition := range conditions {
-			for _, container := range i.containers {
-				if condition(field, container) {
-					value := reflect.ValueOf(container.value)
-					field.value.Set(value)
-					continue Next
-				}
+		matches, err := i.resolve(field, indexes)
+		if err != nil {
+			errs = append(errs, fieldError{owner: field.owner, err: err})
+			continue
+		}
+		bindings[field.owner] = append(bindings[field.owner], binding{field: field, matches: matches})
+		if field.isContainers() {
+			// The containers are meta data, they are not dependencies.
+			continue
+		}
+		for _, match := range matches {
+			// The inherited containers belong to the Graph of their own inventory.
+			if !local[match] {
+				continue
+			}
+			needs.addEdge(field.owner, match)
+			if !field.lazy {
+				graph.addEdge(field.owner, match)
+			}
+		}
+	}
+
+	// The lazy components are only kept if they are needed by the other components.
+	var roots []*container
+	for _, container := range i.containers {
+		if !container.lazy {
+			roots = append(roots, container)
+		}
+	}
+	needed := needs.reachable(roots)
+	i.graph = graph.subgraph(needed)
+
+	var failures Errors
+	for _, err := range errs {
+		if needed[err.owner] {
+			failures = append(failures, err.err)
+		}
+	}
+	order, err := i.graph.Order()
+	if err != nil {
+		failures = append(failures, err)
+	}
+	if err := failures.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	for _, container := range i.graph.containers {
+		if err := i.materialize(container); err != nil {
+			return Errors{err}
+		}
+	}
+
+	// The dependencies of a container are always built before it.
+	for _, c := range order {
+		container := c.(*container)
+		for _, binding := range bindings[container] {
+			i.bind(binding.field, binding.matches)
+		}
+		if container.provider != nil && container.value == nil {
+			// The components depending on it can not be built either.
+			if err := i.build(container); err != nil {
+				return Errors{err}
+			}
+		}
+	}
+	return nil
+}
+
+// materialize initialize the needed lazy component and configure it
+// from the source given to Configure.
+func (i *Inventory) materialize(container *container) error {
+	if !container.lazy {
+		return nil
+	}
+	if container.materialize == nil {
+		// The provided components are materialized once built.
+		container.lazy = false
+		return nil
+	}
+	if err := container.materialize(); err != nil {
+		return err
+	}
+	if i.source != nil {
+		if err := i.configure(i.source, container); err != nil {
+			return err
+		}
+	}
+	// The component stays hidden until it has been fully initialized.
+	container.lazy = false
+	return nil
+}
+
+// Graph return the dependency graph built by Compile, or nil if it was not called yet.
+func (i *Inventory) Graph() *Graph {
+	return i.graph
+}
+
+// Containers return the containers added to the inventory,
+// in the same order than they were added.
+// The lazy containers are only returned once materialized and the containers inherited
+// from a parent inventory are not returned.
+func (i *Inventory) Containers() []Container {
+	containers := make([]Container, 0, len(i.containers))
+	for _, container := range i.containers {
+		if !container.lazy {
+			containers = append(containers, container)
+		}
+	}
+	return containers
+}
+
+// Validate check every component against the rules of its validate tags, the path of the
+// reported fields start with the container name. See the Validate function.
+func (i *Inventory) Validate() error {
+	var errs Errors
+	for _, container := range i.containers {
+		if container.lazy {
+			continue
+		}
+		value := reflect.ValueOf(container.value)
+		if value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct {
+			validateStruct(value.Elem(), container.name, &errs)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// ConfigurationSource define a source of configuration split into sections,
+// such as the config.Loader.
+type ConfigurationSource interface {
+	// Has report if the source define the given section.
+	Has(section string) (bool, error)
+	// Load fills the configuration with the given section,
+	// the violations of its validate tags are reported as Errors of FieldError.
+	Load(section string, cfg interface{}) error
+}
+
+// Configure bind the configuration source to the components, every component implementing
+// the Configurable interface is configured with the section matching its container name.
+// The components whose section is not defined are left untouched.
+//
+// The sections violating the validate tags of their configuration are not applied,
+// every violation is reported as a FieldError whose path starts with the container name,
+// and returned together as Errors once every section has been loaded.
+func (i *Inventory) Configure(source ConfigurationSource) error {
+	i.source = source
+	var violations Errors
+	for _, container := range i.containers {
+		if container.lazy {
+			continue
+		}
+		err := i.configure(source, container)
+		var errs Errors
+		switch {
+		case errors.As(err, &errs):
+			violations = append(violations, errs...)
+		case err != nil:
+			return err
+		}
+	}
+	return violations.ErrorOrNil()
+}
+
+func (i *Inventory) configure(source ConfigurationSource, container *container) error {
+	configurable, ok := container.value.(Configurable)
+	if !ok {
+		return nil
+	}
+
+	found, err := source.Has(container.name)
+	if err != nil || !found {
+		return err
+	}
+
+	cfg := configurable.Configuration()
+	if err := source.Load(container.name, cfg); err != nil {
+		if violations, ok := sectionViolations(err, container.name, cfg); ok {
+			return violations
+		}
+		return err
+	}
+	// The component has already been initialized, its defaults must not override its options.
+	return Apply(container.value, cfg.Options()...)
+}
+
+// sectionViolations report if the error only holds FieldErrors and return them,
+// their paths being rooted at the section name instead of the configuration type.
+func sectionViolations(err error, section string, cfg Configuration) (Errors, bool) {
+	var errs Errors
+	if !errors.As(err, &errs) {
+		return nil, false
+	}
+
+	root := reflect.Indirect(reflect.ValueOf(cfg)).Type().Name()
+	violations := make(Errors, 0, len(errs))
+	for _, err := range errs {
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			return nil, false
+		}
+		violation := *fieldErr
+		violation.Path = section + strings.TrimPrefix(violation.Path, root)
+		violations = append(violations, &violation)
+	}
+	return violations, true
+}
+
+type reconfiguration struct {
+	component Reconfigurable
+	previous  Configuration
+	next      Configuration
+}
+
+// Reconfigure reload the configuration source into the running components, only the components
+// implementing both the Configurable and Reconfigurable interfaces and whose configuration changed
+// are reconfigured.
+// If a component rejects its new configuration, the components already reconfigured
+// are rolled back to their previous configuration.
+func (i *Inventory) Reconfigure(ctx context.Context, source ConfigurationSource) error {
+	var changes []reconfiguration
+	for _, container := range i.containers {
+		if container.lazy {
+			continue
+		}
+		configurable, ok := container.value.(Configurable)
+		if !ok {
+			continue
+		}
+		component, ok := container.value.(Reconfigurable)
+		if !ok {
+			continue
+		}
+
+		found, err := source.Has(container.name)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+
+		previous, next := configurable.Configuration(), configurable.Configuration()
+		if err := source.Load(container.name, next); err != nil {
+			if violations, ok := sectionViolations(err, container.name, next); ok {
+				return violations
+			}
+			return err
+		}
+		if !reflect.DeepEqual(previous, next) {
+			changes = append(changes, reconfiguration{component: component, previous: previous, next: next})
+		}
+	}
+
+	for idx, change := range changes {
+		if err := change.component.Reconfigure(ctx, change.next.Options()); err != nil {
+			for _, applied := range changes[:idx] {
+				// The previous configuration has already been accepted once,
+				// there is not much to do if it is now rejected.
+				_ = applied.component.Reconfigure(ctx, applied.previous.Options())
 			}
+			return err
 		}
 	}
 	return nil
@@ -0,0 +1,710 @@
+package scaffolder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type benchmarkChecker interface {
+	Check() error
+}
+
+type benchmarkLogger struct{}
+
+func (l *benchmarkLogger) Check() error { return nil }
+
+type benchmarkRegistry struct {
+	Checkers []benchmarkChecker
+}
+
+// benchmarkTypes build distinct component types, each one depending on the previous one
+// through its tag, on the benchmarkLogger through the benchmarkChecker interface
+// and on the benchmarkRegistry through its type.
+func benchmarkTypes(size int) []reflect.Type {
+	types := make([]reflect.Type, size)
+	for idx := range types {
+		previous := "logger"
+		if idx > 0 {
+			previous = fmt.Sprintf("component-%d", idx-1)
+		}
+		types[idx] = reflect.PtrTo(reflect.StructOf([]reflect.StructField{
+			{Name: "Previous", Type: reflect.TypeOf((*interface{})(nil)).Elem(), Tag: reflect.StructTag(`scaffolder:"` + previous + `"`)},
+			{Name: "Checker", Type: reflect.TypeOf((*benchmarkChecker)(nil)).Elem()},
+			{Name: "Registry", Type: reflect.TypeOf(&benchmarkRegistry{})},
+		}))
+	}
+	return types
+}
+
+func benchmarkInventory(types []reflect.Type) *Inventory {
+	inventory := New().
+		Add(&benchmarkLogger{}, WithName("logger")).
+		Add(&benchmarkRegistry{})
+	// The components are added in the reverse order of their dependencies.
+	for idx := len(types) - 1; idx >= 0; idx-- {
+		inventory.Add(reflect.New(types[idx].Elem()).Interface(), WithName(fmt.Sprintf("component-%d", idx)))
+	}
+	return inventory
+}
+
+func BenchmarkCompile(b *testing.B) {
+	for _, size := range []int{10, 100, 1000, 5000} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			types := benchmarkTypes(size)
+			for n := 0; n < b.N; n++ {
+				b.StopTimer()
+				inventory := benchmarkInventory(types)
+				b.StartTimer()
+
+				if err := inventory.Compile(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkResolve(b *testing.B) {
+	for _, size := range []int{10, 100, 1000, 5000} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			inventory := benchmarkInventory(benchmarkTypes(size))
+			if err := inventory.Compile(); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if _, err := Resolve[benchmarkChecker](inventory); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// sourceStub is a ConfigurationSource whose sections set the fields of the configurations.
+type sourceStub map[string]map[string]interface{}
+
+func (s sourceStub) Has(section string) (bool, error) {
+	_, ok := s[section]
+	return ok, nil
+}
+
+func (s sourceStub) Load(section string, cfg interface{}) error {
+	value := reflect.ValueOf(cfg).Elem()
+	for name, v := range s[section] {
+		value.FieldByName(name).Set(reflect.ValueOf(v))
+	}
+	return Validate(cfg)
+}
+
+type configurablePrinter struct {
+	envPrinter
+}
+
+func (p *configurablePrinter) Configuration() Configuration {
+	return &configurablePrinterConfig{Level: p.Level}
+}
+
+type configurablePrinterConfig struct {
+	Level string `validate:"oneof=debug info"`
+}
+
+func (c *configurablePrinterConfig) Options() []Option {
+	return []Option{OptionT[configurablePrinter](func(p *configurablePrinter) error {
+		p.Level = c.Level
+		return nil
+	})}
+}
+
+func TestInventoryConfigureKeepsOptions(t *testing.T) {
+	printer := &configurablePrinter{}
+	inventory := New().Add(printer, WithName("printer"), OptionT[configurablePrinter](func(p *configurablePrinter) error {
+		p.Output = "buffer"
+		return nil
+	}))
+
+	err := inventory.Configure(sourceStub{"printer": {"Level": "debug"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if printer.Output != "buffer" {
+		t.Errorf("the option has been overridden by the defaults: %q", printer.Output)
+	}
+	if printer.Level != "debug" {
+		t.Errorf("the configuration has not been applied: %q", printer.Level)
+	}
+}
+
+func TestInventoryConfigureAggregatesViolations(t *testing.T) {
+	first, second, valid := &configurablePrinter{}, &configurablePrinter{}, &configurablePrinter{}
+	inventory := New().
+		Add(first, WithName("first")).
+		Add(second, WithName("second")).
+		Add(valid, WithName("valid"))
+
+	err := inventory.Configure(sourceStub{
+		"first":  {"Level": "verbose"},
+		"second": {"Level": "trace"},
+		"valid":  {"Level": "debug"},
+	})
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected the violations of both sections, got: %v", err)
+	}
+	for idx, path := range []string{"first.Level", "second.Level"} {
+		var fieldErr *FieldError
+		if !errors.As(errs[idx], &fieldErr) || fieldErr.Path != path || !errors.Is(fieldErr, ErrNotOneOf) {
+			t.Errorf("unexpected violation #%d: %v", idx, errs[idx])
+		}
+	}
+	if first.Level != "info" || valid.Level != "debug" {
+		t.Errorf("only the valid sections should be applied: %q, %q", first.Level, valid.Level)
+	}
+}
+
+type greeter interface {
+	Greet() string
+}
+
+type englishGreeter struct{}
+
+func (g *englishGreeter) Greet() string { return "hello" }
+
+type frenchGreeter struct{}
+
+func (g *frenchGreeter) Greet() string { return "bonjour" }
+
+type greeterUser struct {
+	Greeter greeter
+}
+
+type taggedGreeterUser struct {
+	Greeter greeter `scaffolder:"french"`
+}
+
+type englishGreeterUser struct {
+	English *englishGreeter
+}
+
+func TestInventoryResolutionPriority(t *testing.T) {
+	english, french := &englishGreeter{}, &frenchGreeter{}
+	provided := greeter(&englishGreeter{})
+	provide := func() greeter { return provided }
+
+	tests := []struct {
+		name      string
+		inventory func(user Component) *Inventory
+		user      func() (Component, func() interface{})
+		want      interface{}
+		err       error
+	}{
+		{
+			name: "the tag comes first",
+			inventory: func(user Component) *Inventory {
+				return New().Add(user).Provide(provide).Add(english, WithName("Greeter")).Add(french, WithName("french"))
+			},
+			user: func() (Component, func() interface{}) {
+				user := &taggedGreeterUser{}
+				return user, func() interface{} { return user.Greeter }
+			},
+			want: french,
+		},
+		{
+			name: "the name and the type come before the type",
+			inventory: func(user Component) *Inventory {
+				return New().Add(user).Add(&englishGreeter{}, WithName("british")).Add(english, WithName("English"))
+			},
+			user: func() (Component, func() interface{}) {
+				user := &englishGreeterUser{}
+				return user, func() interface{} { return user.English }
+			},
+			want: english,
+		},
+		{
+			name: "the type comes before the interfaces",
+			inventory: func(user Component) *Inventory {
+				return New().Add(user).Add(french).Provide(provide)
+			},
+			user: func() (Component, func() interface{}) {
+				user := &greeterUser{}
+				return user, func() interface{} { return user.Greeter }
+			},
+			want: provided,
+		},
+		{
+			name: "the interface is implemented",
+			inventory: func(user Component) *Inventory {
+				return New().Add(user).Add(french)
+			},
+			user: func() (Component, func() interface{}) {
+				user := &greeterUser{}
+				return user, func() interface{} { return user.Greeter }
+			},
+			want: french,
+		},
+		{
+			name: "several implementations are ambiguous",
+			inventory: func(user Component) *Inventory {
+				return New().Add(user).Add(english).Add(french)
+			},
+			user: func() (Component, func() interface{}) {
+				user := &greeterUser{}
+				return user, func() interface{} { return user.Greeter }
+			},
+			err: &AmbiguityError{},
+		},
+		{
+			name: "the name does not match another type",
+			inventory: func(user Component) *Inventory {
+				return New().Add(user).Add(french, WithName("English"))
+			},
+			user: func() (Component, func() interface{}) {
+				user := &englishGreeterUser{}
+				return user, func() interface{} { return user.English }
+			},
+			err: &UnresolvedError{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user, assigned := test.user()
+			err := test.inventory(user).Compile()
+			if test.err != nil {
+				if err == nil || !errors.As(err, reflect.New(reflect.TypeOf(test.err)).Interface()) {
+					t.Fatalf("expected %T, got: %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := assigned(); got != test.want {
+				t.Errorf("expected %p to be assigned, got %p", test.want, got)
+			}
+		})
+	}
+}
+
+func TestInventoryResolutionScopes(t *testing.T) {
+	english, french := &englishGreeter{}, &frenchGreeter{}
+	parent := New().Add(english, WithName("french"))
+	if err := parent.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		child func(user Component) *Inventory
+		user  func() (Component, func() interface{})
+		want  interface{}
+	}{
+		{
+			name: "the local containers come first",
+			child: func(user Component) *Inventory {
+				return parent.Child().Add(user).Add(french)
+			},
+			user: func() (Component, func() interface{}) {
+				user := &greeterUser{}
+				return user, func() interface{} { return user.Greeter }
+			},
+			want: french,
+		},
+		{
+			name: "the parent containers are inherited",
+			child: func(user Component) *Inventory {
+				return parent.Child().Add(user)
+			},
+			user: func() (Component, func() interface{}) {
+				user := &greeterUser{}
+				return user, func() interface{} { return user.Greeter }
+			},
+			want: english,
+		},
+		{
+			name: "a higher priority comes before the local containers",
+			child: func(user Component) *Inventory {
+				return parent.Child().Add(user).Add(&frenchGreeter{}, WithName("local"))
+			},
+			user: func() (Component, func() interface{}) {
+				user := &taggedGreeterUser{}
+				return user, func() interface{} { return user.Greeter }
+			},
+			want: english,
+		},
+		{
+			name: "a local container shadows the parent one sharing its name",
+			child: func(user Component) *Inventory {
+				return parent.Child().Add(user).AddLazy(french, WithName("french"))
+			},
+			user: func() (Component, func() interface{}) {
+				user := &taggedGreeterUser{}
+				return user, func() interface{} { return user.Greeter }
+			},
+			want: french,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user, assigned := test.user()
+			if err := test.child(user).Compile(); err != nil {
+				t.Fatal(err)
+			}
+			if got := assigned(); got != test.want {
+				t.Errorf("expected %p to be assigned, got %p", test.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveRefreshesTheIndexes(t *testing.T) {
+	parent := New().Add(&englishGreeter{})
+	if err := parent.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	child := parent.Child().Add(&frenchGreeter{})
+	if err := child.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if greeters := ResolveAll[greeter](child); len(greeters) != 2 {
+		t.Fatalf("expected the 2 greeters, got: %v", greeters)
+	}
+
+	parent.Add(&englishGreeter{}, WithName("british"))
+	if err := parent.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if greeters := ResolveAll[greeter](child); len(greeters) != 3 {
+		t.Errorf("the greeter added to the parent is missing: %v", greeters)
+	}
+}
+
+// politeGreeter implements greeter while depending on another one.
+type politeGreeter struct {
+	Greeter greeter
+}
+
+func (g *politeGreeter) Greet() string { return "please, " + g.Greeter.Greet() }
+
+func TestInventoryCompileAmbiguity(t *testing.T) {
+	err := New().
+		Add(&englishGreeter{}, WithName("english")).
+		Add(&frenchGreeter{}, WithName("french")).
+		Add(&greeterUser{}, WithName("first")).
+		Add(&greeterUser{}, WithName("second")).
+		Compile()
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected both ambiguous fields to be reported, got: %v", err)
+	}
+	for idx, container := range []string{"first", "second"} {
+		var ambiguity *AmbiguityError
+		if !errors.As(errs[idx], &ambiguity) || !errors.Is(errs[idx], ErrAmbiguousDependency) {
+			t.Fatalf("unexpected error #%d: %v", idx, errs[idx])
+		}
+		if ambiguity.Container != container || ambiguity.Field != "Greeter" ||
+			!reflect.DeepEqual(ambiguity.Candidates, []string{"english", "french"}) {
+			t.Errorf("unexpected ambiguity #%d: %+v", idx, ambiguity)
+		}
+	}
+}
+
+func TestInventoryCompileIgnoresTheOwner(t *testing.T) {
+	english, polite := &englishGreeter{}, &politeGreeter{}
+	if err := New().Add(english).Add(polite).Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if polite.Greeter != greeter(english) {
+		t.Errorf("the component should not be assigned to its own field: %p", polite.Greeter)
+	}
+}
+
+type greeterConsumer struct {
+	English  *englishGreeter
+	Greeter  greeter         `scaffolder:",optional"`
+	French   *frenchGreeter  `scaffolder:"french,optional"`
+	Ignored  *englishGreeter `scaffolder:"-"`
+	Greeters []greeter
+}
+
+func TestInventoryCompileUnresolved(t *testing.T) {
+	err := New().Add(&greeterConsumer{}, WithName("consumer")).Compile()
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected only the required field to be reported, got: %v", err)
+	}
+	var unresolved *UnresolvedError
+	if !errors.As(errs[0], &unresolved) || !errors.Is(errs[0], ErrUnresolvedDependency) {
+		t.Fatalf("unexpected error: %v", errs[0])
+	}
+	if unresolved.Container != "consumer" || unresolved.Field != "English" ||
+		unresolved.Type != reflect.TypeOf(&englishGreeter{}) {
+		t.Errorf("unexpected unresolved field: %+v", unresolved)
+	}
+}
+
+func TestInventoryCompileOptional(t *testing.T) {
+	english, consumer := &englishGreeter{}, &greeterConsumer{}
+	if err := New().Add(english).Add(consumer).Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if consumer.English != english || consumer.Greeter != greeter(english) {
+		t.Errorf("the resolved fields have not been assigned: %+v", consumer)
+	}
+	if consumer.French != nil || consumer.Ignored != nil {
+		t.Errorf("the optional and ignored fields should stay nil: %+v", consumer)
+	}
+}
+
+type greeterRegistry struct {
+	Greeters   []greeter
+	ByName     map[string]greeter
+	English    []*englishGreeter
+	Containers []Container `scaffolder:"containers"`
+	Languages  []string
+}
+
+func TestInventoryCompileCollections(t *testing.T) {
+	french, english, british := &frenchGreeter{}, &englishGreeter{}, &englishGreeter{}
+	registry := &greeterRegistry{Languages: []string{"fr"}}
+	inventory := New().
+		Add(french, WithName("french")).
+		Add(registry, WithName("registry")).
+		Add(english, WithName("english")).
+		Add(british, WithName("british"))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(registry.Greeters, []greeter{french, english, british}) {
+		t.Errorf("the greeters should be in the order they were added: %v", registry.Greeters)
+	}
+	expected := map[string]greeter{"french": french, "english": english, "british": british}
+	if !reflect.DeepEqual(registry.ByName, expected) {
+		t.Errorf("the greeters should be keyed by their name: %v", registry.ByName)
+	}
+	if !reflect.DeepEqual(registry.English, []*englishGreeter{english, british}) {
+		t.Errorf("unexpected english greeters: %v", registry.English)
+	}
+	if names := containerNamesOf(registry.Containers); !reflect.DeepEqual(names, []string{"french", "registry", "english", "british"}) {
+		t.Errorf("unexpected containers: %v", names)
+	}
+	if !reflect.DeepEqual(registry.Languages, []string{"fr"}) {
+		t.Errorf("the other slices should be left untouched: %v", registry.Languages)
+	}
+	if names := containerNamesOf(inventory.Graph().Dependencies(inventory.Containers()[1])); len(names) != 3 {
+		t.Errorf("the registry should depend on the greeters only: %v", names)
+	}
+}
+
+func TestInventoryCompileMapDuplicateNames(t *testing.T) {
+	err := New().
+		Add(&englishGreeter{}, WithName("greeter")).
+		Add(&frenchGreeter{}, WithName("greeter")).
+		Add(&greeterRegistry{}).
+		Compile()
+	if !errors.Is(err, ErrAmbiguousDependency) {
+		t.Errorf("expected the duplicate names to be ambiguous, got: %v", err)
+	}
+}
+
+type lazyCache struct {
+	Ready    bool
+	Database *providedDatabase
+}
+
+func (c *lazyCache) Default() { c.Ready = true }
+
+type lazyCacheUser struct {
+	Cache *lazyCache
+}
+
+// lazyBroken can not be resolved, it is only reported if needed.
+type lazyBroken struct {
+	Repository *providedRepository
+}
+
+func TestInventoryAddLazyUnused(t *testing.T) {
+	cache := &lazyCache{}
+	inventory := New().
+		AddLazy(cache).
+		AddLazy(&lazyBroken{}).
+		ProvideLazy(func() *providedDatabase {
+			t.Error("the unused database should not be built")
+			return &providedDatabase{}
+		})
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Ready || len(inventory.Containers()) != 0 {
+		t.Errorf("the unused components should not be materialized: %+v, %d", cache, len(inventory.Containers()))
+	}
+}
+
+func TestInventoryAddLazyNeeded(t *testing.T) {
+	cache, user, printer := &lazyCache{}, &lazyCacheUser{}, &configurablePrinter{}
+	inventory := New().
+		AddLazy(cache).
+		AddLazy(&lazyBroken{}).
+		AddLazy(printer, WithName("printer")).
+		ProvideLazy(func() *providedDatabase { return &providedDatabase{dsn: "postgres://test"} }).
+		Add(user)
+	if err := inventory.Configure(sourceStub{"printer": {"Level": "debug"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Cache != cache || !cache.Ready || cache.Database == nil || cache.Database.dsn != "postgres://test" {
+		t.Errorf("the needed components should be materialized: %+v", cache)
+	}
+	if printer.Level != "" {
+		t.Errorf("the unused component should not be configured: %q", printer.Level)
+	}
+	order, err := inventory.Graph().Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := containerNamesOf(order); !reflect.DeepEqual(names, []string{"providedDatabase", "lazyCache", "lazyCacheUser"}) {
+		t.Errorf("unexpected order: %v", names)
+	}
+}
+
+func TestInventoryAddLazyConfigured(t *testing.T) {
+	printer := &configurablePrinter{}
+	inventory := New().
+		AddLazy(printer, WithName("printer")).
+		Add(&struct{ Printer *configurablePrinter }{})
+	if err := inventory.Configure(sourceStub{"printer": {"Level": "debug"}}); err != nil {
+		t.Fatal(err)
+	}
+	if printer.Level != "" {
+		t.Fatalf("the lazy component should not be configured before it is needed: %q", printer.Level)
+	}
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if printer.Output != "stderr" || printer.Level != "debug" {
+		t.Errorf("the materialized component should be configured: %q, %q", printer.Output, printer.Level)
+	}
+}
+
+type greeterMetadata struct {
+	Metadata map[string]interface{}
+	Tags     []interface{}
+}
+
+func TestInventoryCompileEmptyInterfaceCollections(t *testing.T) {
+	metadata := &greeterMetadata{}
+	err := New().
+		Add(&englishGreeter{}, WithName("greeter")).
+		Add(&frenchGreeter{}, WithName("greeter")).
+		Add(metadata).
+		Compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.Metadata != nil || metadata.Tags != nil {
+		t.Errorf("the empty interface collections should be left untouched: %+v", metadata)
+	}
+}
+
+func TestInventoryAddLazyFailure(t *testing.T) {
+	failure := errors.New("connection refused")
+	inventory := New().
+		AddLazy(&lazyCache{}, OptionT[lazyCache](func(*lazyCache) error { return failure })).
+		ProvideLazy(func() *providedDatabase { return &providedDatabase{} }).
+		Add(&lazyCacheUser{})
+	for run := 0; run < 2; run++ {
+		if err := inventory.Compile(); !errors.Is(err, failure) {
+			t.Fatalf("expected the failure to be returned by every Compile, got: %v", err)
+		}
+	}
+	for _, container := range inventory.Containers() {
+		if container.Name() == "lazyCache" {
+			t.Error("the component which failed to be materialized should not be returned")
+		}
+	}
+	if _, err := Resolve[*lazyCache](inventory); !errors.Is(err, ErrUnresolvedDependency) {
+		t.Errorf("the component which failed to be materialized should not be resolved, got: %v", err)
+	}
+}
+
+// reconfigurablePrinter rejects its new configuration if its output is "readonly".
+type reconfigurablePrinter struct {
+	configurablePrinter
+	reconfigured int
+}
+
+func (p *reconfigurablePrinter) Reconfigure(ctx context.Context, opts []Option) error {
+	if p.Output == "readonly" {
+		return errors.New("the printer can not be reconfigured")
+	}
+	cp := p.configurablePrinter
+	if err := Apply(&cp, opts...); err != nil {
+		return err
+	}
+	p.configurablePrinter = cp
+	p.reconfigured++
+	return nil
+}
+
+func TestInventoryReconfigure(t *testing.T) {
+	first, second, unchanged := &reconfigurablePrinter{}, &reconfigurablePrinter{}, &reconfigurablePrinter{}
+	inventory := New().
+		Add(first, WithName("first")).
+		Add(second, WithName("second")).
+		Add(unchanged, WithName("unchanged"))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := inventory.Reconfigure(context.Background(), sourceStub{
+		"first":     {"Level": "debug"},
+		"second":    {"Level": "debug"},
+		"unchanged": {"Level": "info"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Level != "debug" || second.Level != "debug" {
+		t.Errorf("the changed configurations should be applied: %q, %q", first.Level, second.Level)
+	}
+	if unchanged.reconfigured != 0 {
+		t.Errorf("the unchanged component should not be reconfigured: %d", unchanged.reconfigured)
+	}
+}
+
+func TestInventoryReconfigureRollback(t *testing.T) {
+	first, second := &reconfigurablePrinter{}, &reconfigurablePrinter{}
+	inventory := New().
+		Add(first, WithName("first")).
+		Add(second, WithName("second"), OptionT[reconfigurablePrinter](func(p *reconfigurablePrinter) error {
+			p.Output = "readonly"
+			return nil
+		}))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := inventory.Reconfigure(context.Background(), sourceStub{
+		"first":  {"Level": "debug"},
+		"second": {"Level": "debug"},
+	})
+	if err == nil {
+		t.Fatal("expected the second printer to reject its configuration")
+	}
+	if first.Level != "info" || first.reconfigured != 2 {
+		t.Errorf("the first printer should be rolled back: %q after %d reconfigurations", first.Level, first.reconfigured)
+	}
+	if second.Level != "info" {
+		t.Errorf("the second printer should be left untouched: %q", second.Level)
+	}
+}
@@ -1,8 +1,12 @@
 package scaffolder
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"runtime"
+	"strings"
 )
 
 var (
@@ -11,14 +15,55 @@ var (
 	// ErrInvalidOption is returned if the given Option does not respect the prototype:
 	// func(pointer) error.
 	ErrInvalidOption = errors.New("the option does not respect the mandatory prototype")
+	// ErrUnmatchedOption is returned in strict mode if an option did not apply to the target.
+	ErrUnmatchedOption = errors.New("the option does not match the target")
 
 	errUnmatchingTargetType = errors.New("unmatching target type and option argument")
 )
 
-// Defaulter optional interface which can be used to attach default values
-// to a component.
-type Defaulter interface {
-	Default()
+// InitError describe the failure to initialize a component, the Index and the Option
+// are only set if the failure comes from an option.
+type InitError struct {
+	// Component is the type of the component being initialized.
+	Component reflect.Type
+	// Container is the name of the component container, if it was added to an Inventory.
+	Container string
+	// Index is the position of the failing option, or -1.
+	Index int
+	// Option is the name of the failing option, see optionName.
+	Option string
+	Err    error
+}
+
+func (e *InitError) Error() string {
+	var builder strings.Builder
+	fmt.Fprint(&builder, e.Component)
+	if e.Container != "" {
+		fmt.Fprintf(&builder, " %q", e.Container)
+	}
+	if e.Index >= 0 {
+		fmt.Fprintf(&builder, ": option #%d %s", e.Index, e.Option)
+	}
+	fmt.Fprintf(&builder, ": %s", e.Err)
+	return builder.String()
+}
+
+// Unwrap return the underlying error.
+func (e *InitError) Unwrap() error {
+	return e.Err
+}
+
+// withComponent attach the component description to the error,
+// wrapping it into an InitError if it is not one already.
+func withComponent(err error, component reflect.Type, container string) error {
+	var initErr *InitError
+	if !errors.As(err, &initErr) {
+		return &InitError{Component: component, Container: container, Index: -1, Err: err}
+	}
+	cp := *initErr
+	cp.Component = component
+	cp.Container = container
+	return &cp
 }
 
 // Option are generic functor used to configure a component,
@@ -53,14 +98,15 @@ func validate(o Option, target reflect.Type) error {
 	return nil
 }
 
-// Init will take care of initializing the given Component by first calling
-// the default method, if the component implements the Defaulter interface.
+// Init will take care of initializing the given Component by first assigning
+// the values of the default tags, then calling the default method,
+// if the component implements one of the Defaulter, DefaulterE or ContextDefaulter interfaces.
 //
 // Afterward, it will iterate through the list of options
 // and apply them one after another.
 //
 //   type Form struct {
-//   	Age       int
+//   	Age       int `default:"18"`
 //   	FirstName string
 //   }
 //
@@ -75,29 +121,193 @@ func validate(o Option, target reflect.Type) error {
 //   	}
 //   }
 func Init(target Component, opts ...Option) error {
+	return InitContext(context.Background(), target, opts...)
+}
+
+// InitContext behave like Init and give the context to the components
+// implementing the ContextDefaulter interface.
+//
+// The errors are returned as InitError describing the component and the failing option.
+func InitContext(ctx context.Context, target Component, opts ...Option) error {
 	targetType := reflect.TypeOf(target)
-	if targetType.Kind() != reflect.Ptr {
-		return ErrInvalidOption
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return ErrInvalidTarget
 	}
-	if defaulter, ok := target.(Defaulter); ok {
-		defaulter.Default()
+	if err := setDefaults(ctx, target); err != nil {
+		return withComponent(err, targetType, "")
 	}
+	return apply(target, opts, nil)
+}
 
+// InitStrict behave like Init but return an UnmatchedOptionError
+// if any of the options does not match the target.
+func InitStrict(target Component, opts ...Option) error {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return ErrInvalidTarget
+	}
+	if err := setDefaults(context.Background(), target); err != nil {
+		return withComponent(err, targetType, "")
+	}
+
+	applied := make([]bool, len(opts))
+	if err := apply(target, opts, applied); err != nil {
+		return err
+	}
+	return unmatchedOptions(targetType, opts, applied)
+}
+
+// Apply the options matching the target without assigning the default values,
+// it is intended to update a component which has already been initialized.
+func Apply(target Component, opts ...Option) error {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return ErrInvalidTarget
+	}
+	return apply(target, opts, nil)
+}
+
+// apply calls every option matching the target,
+// the applied slice is optional and keep track of the matching options.
+func apply(target Component, opts []Option, applied []bool) error {
 	targetValue := reflect.ValueOf(target)
-	args := []reflect.Value{targetValue}
-
-	for _, opt := range opts {
-		err := validate(opt, targetType)
-		switch {
-		case err == errUnmatchingTargetType:
-			continue
-		case err != nil:
-			return err
+	for idx, opt := range opts {
+		matched, err := applyOption(opt, targetValue)
+		if matched && applied != nil {
+			applied[idx] = true
 		}
+		if err != nil {
+			return &InitError{
+				Component: targetValue.Type(),
+				Index:     idx,
+				Option:    optionName(opt),
+				Err:       err,
+			}
+		}
+	}
+	return nil
+}
+
+// applyOption calls the option if it matches the target and report if it did.
+func applyOption(opt Option, target reflect.Value) (bool, error) {
+	if c, ok := opt.(composite); ok {
+		return c.apply(target)
+	}
 
-		returnedValues := reflect.ValueOf(opt).Call(args)
-		if err, _ := returnedValues[0].Interface().(error); err != nil {
-			return err
+	err := validate(opt, target.Type())
+	switch {
+	case err == errUnmatchingTargetType:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	returnedValues := reflect.ValueOf(opt).Call([]reflect.Value{target})
+	err, _ = returnedValues[0].Interface().(error)
+	return true, err
+}
+
+// matchOption report if the option would apply to the target, without calling it.
+func matchOption(opt Option, target reflect.Type) (bool, error) {
+	if c, ok := opt.(composite); ok {
+		return c.matches(target)
+	}
+
+	err := validate(opt, target)
+	switch {
+	case err == errUnmatchingTargetType:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+// UnmatchedOptionError is returned in strict mode and describe
+// every option which did not apply to the target.
+type UnmatchedOptionError struct {
+	Target  reflect.Type
+	Options []string
+}
+
+func (e *UnmatchedOptionError) Error() string {
+	return fmt.Sprintf("%s: %s: %s",
+		e.Target, ErrUnmatchedOption, strings.Join(e.Options, ", "))
+}
+
+// Unwrap let the error be compared with ErrUnmatchedOption.
+func (e *UnmatchedOptionError) Unwrap() error {
+	return ErrUnmatchedOption
+}
+
+func unmatchedOptions(target reflect.Type, opts []Option, applied []bool) error {
+	var names []string
+	for idx, opt := range opts {
+		if !applied[idx] {
+			names = append(names, optionName(opt))
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return &UnmatchedOptionError{Target: target, Options: names}
+}
+
+// optionName describe an option with the name of the function which built it
+// and the type it expects, such as: healthcheck.WithInterval(*healthcheck.HTTPHandler).
+func optionName(opt Option) string {
+	if stringer, ok := opt.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	oType := reflect.TypeOf(opt)
+	if oType == nil || oType.Kind() != reflect.Func {
+		return fmt.Sprintf("%T", opt)
+	}
+
+	name := "anonymous option"
+	if fn := runtime.FuncForPC(reflect.ValueOf(opt).Pointer()); fn != nil {
+		name = fn.Name()
+		name = name[strings.LastIndex(name, "/")+1:]
+		// Closures are named after their parent: healthcheck.WithInterval.func1.
+		if idx := strings.LastIndex(name, ".func"); idx > 0 {
+			name = name[:idx]
+		}
+	}
+	if oType.NumIn() == 1 {
+		return fmt.Sprintf("%s(%s)", name, oType.In(0))
+	}
+	return name
+}
+
+// OptionT is the compile-time checked counterpart of Option,
+// the compiler guarantees that it respects the prototype: func(*T) error.
+//
+//   func WithAge(age int) scaffolder.OptionT[Form] {
+//   	return func(f *Form) error {
+//   		f.Age = age
+//   		return nil
+//   	}
+//   }
+//
+// Its underlying type being a function, an OptionT can be given anywhere an Option
+// is expected, such as Init, Inventory.Add or application.WithComponent.
+type OptionT[T any] func(*T) error
+
+// InitT is the compile-time checked counterpart of Init, it assigns the default values
+// the same way than Init and then apply the options one after another.
+func InitT[T any](target *T, opts ...OptionT[T]) error {
+	if target == nil {
+		return ErrInvalidTarget
+	}
+	targetType := reflect.TypeOf(target)
+	if err := setDefaults(context.Background(), target); err != nil {
+		return withComponent(err, targetType, "")
+	}
+
+	for idx, opt := range opts {
+		if err := opt(target); err != nil {
+			return &InitError{Component: targetType, Index: idx, Option: optionName(opt), Err: err}
 		}
 	}
 	return nil
@@ -109,6 +319,39 @@ type Configuration interface {
 	Options() []Option
 }
 
+// Configurable optional interface for the components which can be configured
+// from a configuration source, such as a configuration file.
+// It returns the Configuration to be filled from the section named after the component container.
+//
+// The returned Configuration should hold the current values of the component,
+// the keys missing from the configuration source would otherwise reset them.
+//
+//   func (f *Form) Configuration() scaffolder.Configuration {
+//   	return &Config{FirstName: f.FirstName, Age: f.Age}
+//   }
+type Configurable interface {
+	Configuration() Configuration
+}
+
+// Reconfigurable optional interface for the components which can be reconfigured
+// while running. The options must be applied atomically and the component
+// left untouched if an error is returned.
+//
+//   func (f *Form) Reconfigure(ctx context.Context, opts []scaffolder.Option) error {
+//   	f.mutex.Lock()
+//   	defer f.mutex.Unlock()
+//
+//   	cp := Form{FirstName: f.FirstName, Age: f.Age}
+//   	if err := scaffolder.Apply(&cp, opts...); err != nil {
+//   		return err
+//   	}
+//   	f.FirstName, f.Age = cp.FirstName, cp.Age
+//   	return nil
+//   }
+type Reconfigurable interface {
+	Reconfigure(ctx context.Context, opts []Option) error
+}
+
 // Configure apply the Options returned by the Configuration.
 //
 //   type Config struct {
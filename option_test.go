@@ -0,0 +1,142 @@
+package scaffolder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInitStrict(t *testing.T) {
+	printer := &envPrinter{}
+	if err := InitStrict(printer, withOutput("buffer")); err != nil {
+		t.Fatal(err)
+	}
+	if printer.Output != "buffer" || printer.Level != "info" {
+		t.Errorf("the defaults and the options should be applied: %+v", printer)
+	}
+
+	err := InitStrict(&envPrinter{}, withOutput("buffer"), withFormName("Erika"), WithName("printer"))
+	var unmatched *UnmatchedOptionError
+	if !errors.As(err, &unmatched) || !errors.Is(err, ErrUnmatchedOption) {
+		t.Fatalf("expected an UnmatchedOptionError, got: %v", err)
+	}
+	expected := []string{"scaffolder.withFormName(*scaffolder.combinedForm)", "scaffolder.WithName(*scaffolder.container)"}
+	if unmatched.Target != reflect.TypeOf(&envPrinter{}) || !reflect.DeepEqual(unmatched.Options, expected) {
+		t.Errorf("expected the options %v, got: %+v", expected, unmatched)
+	}
+}
+
+func TestInventoryStrictMode(t *testing.T) {
+	// The options are shared between the component and its container,
+	// they are only unmatched if they apply to neither of them.
+	printer := &envPrinter{}
+	inventory := New(WithStrictMode()).Add(printer, WithName("printer"), withOutput("buffer"))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if printer.Output != "buffer" || inventory.Containers()[0].Name() != "printer" {
+		t.Errorf("the options should be applied to the component and its container: %+v", printer)
+	}
+
+	err := New(WithStrictMode()).Add(&envPrinter{}, WithName("printer"), withFormName("Erika")).Compile()
+	var unmatched *UnmatchedOptionError
+	if !errors.As(err, &unmatched) || !reflect.DeepEqual(unmatched.Options, []string{"scaffolder.withFormName(*scaffolder.combinedForm)"}) {
+		t.Errorf("expected only the option of the form to be unmatched, got: %v", err)
+	}
+
+	if err := New().Add(&envPrinter{}, withFormName("Erika")).Compile(); err != nil {
+		t.Errorf("the unmatched options should be skipped outside of the strict mode, got: %v", err)
+	}
+}
+
+var errInvalidOutput = errors.New("invalid output")
+
+func withFailingOutput() OptionT[envPrinter] {
+	return func(p *envPrinter) error {
+		return errInvalidOutput
+	}
+}
+
+func TestInitError(t *testing.T) {
+	err := Init(&envPrinter{}, withOutput("buffer"), withFailingOutput())
+
+	var initErr *InitError
+	if !errors.As(err, &initErr) || !errors.Is(err, errInvalidOutput) {
+		t.Fatalf("expected an InitError, got: %v", err)
+	}
+	expected := InitError{
+		Component: reflect.TypeOf(&envPrinter{}),
+		Index:     1,
+		Option:    "scaffolder.withFailingOutput(*scaffolder.envPrinter)",
+		Err:       errInvalidOutput,
+	}
+	if *initErr != expected {
+		t.Errorf("expected %+v, got: %+v", expected, *initErr)
+	}
+	if message := "*scaffolder.envPrinter: option #1 scaffolder.withFailingOutput(*scaffolder.envPrinter): invalid output"; err.Error() != message {
+		t.Errorf("expected %q, got: %q", message, err.Error())
+	}
+}
+
+func TestInventoryInitErrors(t *testing.T) {
+	err := New().
+		Add(&envPrinter{}, WithName("first"), withFailingOutput()).
+		Add(&envPrinter{}, WithName("valid")).
+		Add(&envPrinter{}, WithName("second"), withOutput("buffer"), withFailingOutput()).
+		Add(envPrinter{}).
+		Compile()
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("expected every failing Add to be reported, got: %v", err)
+	}
+	expected := []InitError{
+		{Component: reflect.TypeOf(&envPrinter{}), Container: "first", Index: 1, Option: "scaffolder.withFailingOutput(*scaffolder.envPrinter)", Err: errInvalidOutput},
+		{Component: reflect.TypeOf(&envPrinter{}), Container: "second", Index: 2, Option: "scaffolder.withFailingOutput(*scaffolder.envPrinter)", Err: errInvalidOutput},
+		{Component: reflect.TypeOf(envPrinter{}), Index: -1, Err: ErrInvalidComponent},
+	}
+	for idx := range expected {
+		var initErr *InitError
+		if !errors.As(errs[idx], &initErr) || *initErr != expected[idx] {
+			t.Errorf("expected %+v, got: %v", expected[idx], errs[idx])
+		}
+	}
+}
+
+func TestOptionT(t *testing.T) {
+	withLevel := func(p *envPrinter) error {
+		p.Level = "debug"
+		return nil
+	}
+
+	printer := &envPrinter{}
+	if err := InitT(printer, withOutput("buffer"), withLevel); err != nil {
+		t.Fatal(err)
+	}
+	if printer.Output != "buffer" || printer.Level != "debug" {
+		t.Errorf("InitT should apply the defaults and the options: %+v", printer)
+	}
+	if err := InitT[envPrinter](nil); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("expected %v, got: %v", ErrInvalidTarget, err)
+	}
+
+	mixed := &envPrinter{}
+	if err := Init(mixed, withOutput("buffer"), withLevel); err != nil {
+		t.Fatal(err)
+	}
+	if mixed.Output != "buffer" || mixed.Level != "debug" {
+		t.Errorf("Init should accept the OptionT next to the reflective options: %+v", mixed)
+	}
+
+	added := &envPrinter{}
+	inventory := New().Add(added, WithName("printer"), withOutput("buffer"), withLevel)
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if added.Output != "buffer" || added.Level != "debug" {
+		t.Errorf("Add should accept the OptionT next to the reflective options: %+v", added)
+	}
+	if resolved, err := ResolveNamed[*envPrinter](inventory, "printer"); err != nil || resolved != added {
+		t.Errorf("the reflective option should still name the container: %v, %v", resolved, err)
+	}
+}
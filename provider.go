@@ -0,0 +1,144 @@
+package scaffolder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrInvalidProvider is returned if the constructor given to Provide does not respect
+	// the prototype: func(dependencies...) (component, error) or func(dependencies...) component.
+	ErrInvalidProvider = errors.New("the constructor does not respect the mandatory prototype")
+	// ErrNilComponent is returned if a constructor returned neither a component nor an error.
+	ErrNilComponent = errors.New("the constructor returned a nil component")
+)
+
+// provider build a component from its constructor once its dependencies have been resolved.
+type provider struct {
+	constructor reflect.Value
+	// params are resolved like the fields of a component, without any name or tag.
+	params  []field
+	opts    []Option
+	applied []bool
+}
+
+// Provide register a constructor building a component from its dependencies,
+// it lets the components keep their fields unexported and immutable.
+//
+// The constructor must return a pointer or an interface, optionally followed by an error.
+// Its parameters are resolved with the same priority than the fields of a component,
+// ignoring the rules based on the name and the tag, and must be either pointers,
+// interfaces, slices or maps.
+//
+//   func NewServer(logger logger.Logger, checkers []healthcheck.Checker) (*Server, error) {
+//   	return &Server{logger: logger, checkers: checkers}, nil
+//   }
+//
+//   inventory.Provide(NewServer, WithName("server"))
+//
+// The container is named after the returned type unless the WithName Option is given,
+// the other options are applied to the component once built.
+// The constructor is only called by Compile, after its dependencies have been built,
+// the component is therefore not configured by the Configure method and its own fields
+// are not injected.
+func (i *Inventory) Provide(constructor interface{}, opts ...Option) *Inventory {
+	return i.registerProvider(constructor, opts, false)
+}
+
+// ProvideLazy behave like Provide, but the constructor is only called if the component
+// is needed by another component, see AddLazy.
+func (i *Inventory) ProvideLazy(constructor interface{}, opts ...Option) *Inventory {
+	return i.registerProvider(constructor, opts, true)
+}
+
+// registerProvider add the provided container and the parameters of its constructor
+// to the inventory, or record the failure.
+func (i *Inventory) registerProvider(constructor interface{}, opts []Option, lazy bool) *Inventory {
+	container, err := i.provide(constructor, opts)
+	if err != nil {
+		i.errs = append(i.errs, err)
+		return i
+	}
+
+	container.lazy = lazy
+	i.containers = append(i.containers, container)
+	i.revision++
+	i.fields = append(i.fields, container.provider.params...)
+	return i
+}
+
+func (i *Inventory) provide(constructor interface{}, opts []Option) (*container, error) {
+	cType := reflect.TypeOf(constructor)
+	switch {
+	case cType == nil:
+		fallthrough
+	case cType.Kind() != reflect.Func:
+		fallthrough
+	case cType.NumOut() < 1 || cType.NumOut() > 2:
+		fallthrough
+	case cType.NumOut() == 2 && !cType.Out(1).Implements(errorInterface):
+		fallthrough
+	case cType.Out(0).Kind() != reflect.Ptr && cType.Out(0).Kind() != reflect.Interface:
+		return nil, &InitError{Component: cType, Index: -1, Err: ErrInvalidProvider}
+	}
+
+	// The component does not exist yet, the options are kept to be applied once it is built.
+	applied := make([]bool, len(opts))
+	container := &container{t: cType.Out(0)}
+	if err := setDefaults(context.Background(), container); err != nil {
+		return nil, withComponent(err, container.t, container.name)
+	}
+	if err := apply(container, opts, applied); err != nil {
+		return nil, withComponent(err, container.t, container.name)
+	}
+
+	params := make([]field, cType.NumIn())
+	for idx := range params {
+		paramType := cType.In(idx)
+		if !i.isSettableType(paramType.Kind()) {
+			err := fmt.Errorf("%w: unsupported parameter #%d %s", ErrInvalidProvider, idx, paramType)
+			return nil, withComponent(err, container.t, container.name)
+		}
+		params[idx] = field{
+			owner: container,
+			value: reflect.New(paramType).Elem(),
+			t:     paramType,
+			name:  fmt.Sprintf("#%d", idx),
+		}
+	}
+
+	container.provider = &provider{
+		constructor: reflect.ValueOf(constructor),
+		params:      params,
+		opts:        opts,
+		applied:     applied,
+	}
+	return container, nil
+}
+
+// build calls the constructor with the resolved parameters and initialize the returned component.
+func (i *Inventory) build(container *container) error {
+	provider := container.provider
+	args := make([]reflect.Value, len(provider.params))
+	for idx, param := range provider.params {
+		args[idx] = param.value
+	}
+
+	var returned []reflect.Value
+	if provider.constructor.Type().IsVariadic() {
+		returned = provider.constructor.CallSlice(args)
+	} else {
+		returned = provider.constructor.Call(args)
+	}
+	if len(returned) == 2 && !returned[1].IsNil() {
+		return withComponent(returned[1].Interface().(error), container.t, container.name)
+	}
+	if returned[0].IsNil() {
+		return withComponent(ErrNilComponent, container.t, container.name)
+	}
+
+	container.value = returned[0].Interface()
+	return i.initialize(container, provider.opts, provider.applied)
+}
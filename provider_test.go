@@ -0,0 +1,125 @@
+package scaffolder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type providedDatabase struct {
+	dsn string
+}
+
+type providedRepository struct {
+	database *providedDatabase
+}
+
+type providedService struct {
+	Repository *providedRepository
+	Greeters   []greeter
+}
+
+func TestInventoryProvide(t *testing.T) {
+	var built []string
+	service, english := &providedService{}, &englishGreeter{}
+	inventory := New().
+		Add(service).
+		Provide(func(database *providedDatabase, greeters []greeter) (*providedRepository, error) {
+			built = append(built, "repository")
+			return &providedRepository{database: database}, nil
+		}).
+		Provide(func() *providedDatabase {
+			built = append(built, "database")
+			return &providedDatabase{dsn: "postgres://test"}
+		}, WithName("database")).
+		Add(english)
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(built, []string{"database", "repository"}) {
+		t.Errorf("the dependencies should be built first: %v", built)
+	}
+	if service.Repository == nil || service.Repository.database.dsn != "postgres://test" {
+		t.Errorf("the provided components have not been assigned: %+v", service.Repository)
+	}
+	if names := containerNamesOf(inventory.Containers()); !reflect.DeepEqual(names, []string{"providedService", "providedRepository", "database", "englishGreeter"}) {
+		t.Errorf("unexpected containers: %v", names)
+	}
+	if repository, err := Resolve[*providedRepository](inventory); err != nil || repository != service.Repository {
+		t.Errorf("the provided component should be resolved: %v, %v", repository, err)
+	}
+}
+
+func TestInventoryProvideErrors(t *testing.T) {
+	failure := errors.New("connection refused")
+	tests := []struct {
+		name      string
+		inventory *Inventory
+		err       error
+	}{
+		{
+			name:      "the constructor fails",
+			inventory: New().Provide(func() (*providedDatabase, error) { return nil, failure }),
+			err:       failure,
+		},
+		{
+			name:      "the constructor returns a nil component",
+			inventory: New().Provide(func() *providedDatabase { return nil }),
+			err:       ErrNilComponent,
+		},
+		{
+			name:      "a parameter is unresolved",
+			inventory: New().Provide(func(*providedDatabase) *providedRepository { return nil }),
+			err:       ErrUnresolvedDependency,
+		},
+		{
+			name:      "a parameter is not a component",
+			inventory: New().Provide(func(string) *providedRepository { return nil }),
+			err:       ErrInvalidProvider,
+		},
+		{
+			name:      "the second value is not an error",
+			inventory: New().Provide(func() (*providedDatabase, bool) { return nil, false }),
+			err:       ErrInvalidProvider,
+		},
+		{
+			name:      "the constructor is not a function",
+			inventory: New().Provide(&providedDatabase{}),
+			err:       ErrInvalidProvider,
+		},
+		{
+			name: "an option does not match the component",
+			inventory: New(WithStrictMode()).Provide(func() *providedDatabase { return &providedDatabase{} },
+				OptionT[providedRepository](func(*providedRepository) error { return nil })),
+			err: ErrUnmatchedOption,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.inventory.Compile(); !errors.Is(err, test.err) {
+				t.Errorf("expected %v, got: %v", test.err, err)
+			}
+		})
+	}
+}
+
+// greeterFunc is a provided component which is not a pointer, nor comparable.
+type greeterFunc func() string
+
+func (f greeterFunc) Greet() string { return f() }
+
+func TestInventoryProvideNotComparable(t *testing.T) {
+	user := &greeterUser{}
+	inventory := New().
+		Provide(func() greeter { return greeterFunc(func() string { return "hi" }) }).
+		Add(user)
+	for run := 0; run < 2; run++ {
+		if err := inventory.Compile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if user.Greeter == nil || user.Greeter.Greet() != "hi" {
+		t.Errorf("the provided function should be assigned: %v", user.Greeter)
+	}
+}
@@ -0,0 +1,121 @@
+package scaffolder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrReplacement is returned if a replacement given to Replace does not match exactly one component.
+var ErrReplacement = errors.New("unable to replace the component")
+
+// Replace swap a component of the inventory for the given one, such as a fake in the
+// integration tests. The replaced component is matched by the container name of the replacement,
+// as given to WithName or named after its type, and otherwise by its exact type.
+//
+// A replacement of a different type, such as a fake implementing the same interface,
+// is therefore matched by name only and the WithName Option must be given:
+//
+//   inventory.Replace(&FakeDB{}, scaffolder.WithName("PostgresClient"))
+//
+// The fields of the other components must accept the replacement, a fake can not be assigned
+// to the fields expecting the concrete type of the replaced component.
+// Unless the WithName Option is given, the replacement takes the name and the labels
+// of the replaced container, so it is resolved and configured the same way.
+// The replacements are only applied by Compile, they can therefore be given before the
+// component they replace. The replacement is initialized once it has replaced the component,
+// it is configured from the source given to Configure and keeps its laziness, see AddLazy.
+func (i *Inventory) Replace(component Component, opts ...Option) *Inventory {
+	return i.ReplaceContext(context.Background(), component, opts...)
+}
+
+// ReplaceContext behave like Replace and give the context to the components
+// implementing the ContextDefaulter interface.
+func (i *Inventory) ReplaceContext(ctx context.Context, component Component, opts ...Option) *Inventory {
+	container, err := i.add(ctx, component, opts, true)
+	if err != nil {
+		i.errs = append(i.errs, err)
+		return i
+	}
+
+	i.replacements = append(i.replacements, container)
+	return i
+}
+
+// replace swap the components for their replacements, keeping their position in the inventory.
+func (i *Inventory) replace() error {
+	var errs Errors
+	for _, replacement := range i.replacements {
+		idx, err := i.replaced(replacement)
+		if err != nil {
+			errs = append(errs, withComponent(err, replacement.t, replacement.name))
+			continue
+		}
+
+		replaced := i.containers[idx]
+		i.containers[idx] = replacement
+		if !replacement.named {
+			// The replacement matched by type takes the place of the replaced container,
+			// so it is still found by its name, its configuration section and its labels.
+			replacement.name = replaced.name
+			labels := make(map[string]string, len(replaced.labels)+len(replacement.labels))
+			for key, value := range replaced.labels {
+				labels[key] = value
+			}
+			for key, value := range replacement.labels {
+				labels[key] = value
+			}
+			replacement.labels = labels
+		}
+
+		fields := i.fields[:0]
+		for _, field := range i.fields {
+			if field.owner != replaced {
+				fields = append(fields, field)
+			}
+		}
+		extracted, tagErrs := i.extractFields(replacement)
+		i.fields = append(fields, extracted...)
+		if len(tagErrs) > 0 {
+			errs = append(errs, tagErrs...)
+			continue
+		}
+
+		// The replacement of a lazy component stays lazy until it is needed,
+		// the others are initialized right away.
+		if replaced.lazy {
+			continue
+		}
+		if err := i.materialize(replacement); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	i.replacements = nil
+	return errs.ErrorOrNil()
+}
+
+// replaced return the index of the container to be replaced.
+func (i *Inventory) replaced(replacement *container) (int, error) {
+	for idx, container := range i.containers {
+		if container.name == replacement.name {
+			return idx, nil
+		}
+	}
+
+	var matches []int
+	for idx, container := range i.containers {
+		if container.t == replacement.t {
+			matches = append(matches, idx)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return -1, fmt.Errorf("%w: no component named %q or of type %s, use WithName to replace a component of another type",
+			ErrReplacement, replacement.name, replacement.t)
+	case 1:
+		return matches[0], nil
+	default:
+		return -1, fmt.Errorf("%w: %d components of type %s, use WithName to select one",
+			ErrReplacement, len(matches), replacement.t)
+	}
+}
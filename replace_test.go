@@ -0,0 +1,161 @@
+package scaffolder
+
+import (
+	"errors"
+	"testing"
+)
+
+type store interface {
+	Get(key string) string
+}
+
+type postgresStore struct {
+	DSN string
+}
+
+func (s *postgresStore) Get(key string) string { return "postgres:" + key }
+
+type fakeStore struct {
+	Prefix string `default:"fake"`
+	Mode   string
+	Seeded bool
+}
+
+func (s *fakeStore) Default() { s.Mode = "memory" }
+
+func (s *fakeStore) Get(key string) string { return s.Prefix + ":" + key }
+
+type storeUser struct {
+	Store store
+}
+
+func TestInventoryReplaceInitializesTheReplacement(t *testing.T) {
+	user, fake := &storeUser{}, &fakeStore{}
+	inventory := New().
+		Add(user).
+		Add(&postgresStore{}, WithName("store")).
+		Replace(fake, WithName("store"), OptionT[fakeStore](func(s *fakeStore) error {
+			s.Seeded = true
+			return nil
+		}))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Store != store(fake) {
+		t.Fatalf("the replacement has not been injected: %#v", user.Store)
+	}
+	if fake.Prefix != "fake" || fake.Mode != "memory" {
+		t.Errorf("the defaults have not been applied: %q, %q", fake.Prefix, fake.Mode)
+	}
+	if !fake.Seeded {
+		t.Error("the options have not been applied")
+	}
+}
+
+func TestInventoryReplaceConfiguresTheReplacement(t *testing.T) {
+	printer := &configurablePrinter{}
+	inventory := New().
+		Add(&configurablePrinter{}, WithName("printer")).
+		Replace(printer, WithName("printer"))
+	if err := inventory.Configure(sourceStub{"printer": {"Level": "debug"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if printer.Output != "stderr" || printer.Level != "debug" {
+		t.Errorf("the replacement has not been configured: %q, %q", printer.Output, printer.Level)
+	}
+}
+
+func TestInventoryReplaceKeepsTheLaziness(t *testing.T) {
+	fake := &fakeStore{}
+	inventory := New().
+		AddLazy(&postgresStore{}, WithName("store")).
+		Replace(fake, WithName("store"))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if fake.Prefix != "" || fake.Mode != "" {
+		t.Errorf("the replacement of an unused lazy component has been initialized: %#v", fake)
+	}
+}
+
+func TestInventoryReplaceByType(t *testing.T) {
+	user, replacement := &storeUser{}, &postgresStore{DSN: "postgres://test"}
+	inventory := New().
+		Add(user).
+		Add(&postgresStore{}, WithName("primary")).
+		Replace(replacement)
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if user.Store != store(replacement) {
+		t.Errorf("the replacement has not been injected: %#v", user.Store)
+	}
+}
+
+func TestInventoryReplaceErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		inventory *Inventory
+	}{
+		{
+			name:      "a fake of another type must be matched by name",
+			inventory: New().Add(&postgresStore{}, WithName("store")).Replace(&fakeStore{}),
+		},
+		{
+			name: "the type matches several components",
+			inventory: New().
+				Add(&postgresStore{}, WithName("primary")).
+				Add(&postgresStore{}, WithName("secondary")).
+				Replace(&postgresStore{}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.inventory.Compile(); !errors.Is(err, ErrReplacement) {
+				t.Errorf("expected %v, got: %v", ErrReplacement, err)
+			}
+		})
+	}
+}
+
+type labelledStoreUser struct {
+	Store *postgresStore `scaffolder:"label=region:eu"`
+}
+
+func TestInventoryReplaceByTypeKeepsTheContainer(t *testing.T) {
+	user, replacement := &labelledStoreUser{}, &postgresStore{}
+	inventory := New().
+		Add(user).
+		Add(&postgresStore{}, WithName("primary"), WithLabel("region", "eu")).
+		Replace(replacement)
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Store != replacement {
+		t.Errorf("the labels should select the replacement: %#v", user.Store)
+	}
+	if resolved, err := ResolveNamed[*postgresStore](inventory, "primary"); err != nil || resolved != replacement {
+		t.Errorf("the replacement should keep the name of the replaced container: %v, %v", resolved, err)
+	}
+}
+
+func TestInventoryReplaceByTypeKeepsTheSection(t *testing.T) {
+	printer := &configurablePrinter{}
+	inventory := New().
+		Add(&configurablePrinter{}, WithName("printer")).
+		Replace(printer)
+	if err := inventory.Configure(sourceStub{"printer": {"Level": "debug"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if printer.Level != "debug" {
+		t.Errorf("the replacement should be configured from the section of the replaced container: %q", printer.Level)
+	}
+}
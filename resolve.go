@@ -0,0 +1,104 @@
+package scaffolder
+
+import (
+	"reflect"
+)
+
+// available return the indexed scopes of the inventory restricted to the materialized components,
+// the indexes are kept until the inventory or one of its ancestors changes.
+func (i *Inventory) available() []*index {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	revision := i.revisions()
+	if i.indexes != nil && i.indexed == revision {
+		return i.indexes
+	}
+
+	scopes := i.scopes()
+	for idx, scope := range scopes {
+		var containers []*container
+		for _, container := range scope {
+			if !container.lazy && container.value != nil {
+				containers = append(containers, container)
+			}
+		}
+		scopes[idx] = containers
+	}
+	i.indexes = newIndexes(scopes)
+	i.indexed = revision
+	return i.indexes
+}
+
+// revisions count the changes of the inventory and of its ancestors.
+func (i *Inventory) revisions() int {
+	var revisions int
+	for inventory := i; inventory != nil; inventory = inventory.parent {
+		revisions += inventory.revision
+	}
+	return revisions
+}
+
+// Resolve return the component matching the type T, following the same priority than
+// the fields of the components, without the rules based on the name and the tag.
+// It is intended to fetch the components once the inventory has been compiled,
+// such as in the main function or in a plugin loader.
+//
+//   server, err := scaffolder.Resolve[*Server](inventory)
+//
+// An UnresolvedError is returned if no component matches the type T and an AmbiguityError
+// if several components match it with the same priority.
+// Only the materialized components are considered, see AddLazy and Provide.
+func Resolve[T any](i *Inventory) (T, error) {
+	var zero T
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	candidates := i.candidates(field{t: t}, i.available())
+	switch len(candidates) {
+	case 0:
+		return zero, &UnresolvedError{Type: t}
+	case 1:
+		return candidates[0].value.(T), nil
+	default:
+		return zero, &AmbiguityError{Type: t, Candidates: containerNames(candidates)}
+	}
+}
+
+// ResolveNamed return the component of the container with the given name,
+// an UnresolvedError is returned if there is no such container or if its component
+// does not match the type T, and an AmbiguityError if several containers of the same
+// scope share the name, the local containers shadowing the parent ones.
+//
+//   db, err := scaffolder.ResolveNamed[*sql.DB](inventory, "primary")
+func ResolveNamed[T any](i *Inventory, name string) (T, error) {
+	var zero T
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for _, index := range i.available() {
+		containers := index.byName[name]
+		switch {
+		case len(containers) == 0:
+			continue
+		case len(containers) > 1:
+			return zero, &AmbiguityError{Type: t, Candidates: containerNames(containers)}
+		}
+		if component, ok := containers[0].value.(T); ok {
+			return component, nil
+		}
+		return zero, &UnresolvedError{Type: t, Name: name}
+	}
+	return zero, &UnresolvedError{Type: t, Name: name}
+}
+
+// ResolveAll return every component matching the type T, in the order they were added,
+// the same way than the slice fields are filled.
+//
+//   checkers := scaffolder.ResolveAll[healthcheck.Checker](inventory)
+func ResolveAll[T any](i *Inventory) []T {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	indexes := i.available()
+	var components []T
+	for idx := len(indexes) - 1; idx >= 0; idx-- {
+		for _, container := range indexes[idx].assignableTo(t) {
+			components = append(components, container.value.(T))
+		}
+	}
+	return components
+}
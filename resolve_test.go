@@ -0,0 +1,93 @@
+package scaffolder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	english, french, database := &englishGreeter{}, &frenchGreeter{}, &providedDatabase{}
+	inventory := New().
+		Add(database, WithName("database")).
+		Add(english, WithName("english")).
+		Add(french, WithName("french")).
+		AddLazy(&lazyCache{})
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := Resolve[*providedDatabase](inventory); err != nil || got != database {
+		t.Errorf("unexpected database: %v, %v", got, err)
+	}
+	var ambiguity *AmbiguityError
+	if _, err := Resolve[greeter](inventory); !errors.As(err, &ambiguity) ||
+		!reflect.DeepEqual(ambiguity.Candidates, []string{"english", "french"}) {
+		t.Errorf("expected the greeters to be ambiguous, got: %v", err)
+	}
+	if _, err := Resolve[*lazyCache](inventory); !errors.Is(err, ErrUnresolvedDependency) {
+		t.Errorf("the unused lazy component should not be resolved, got: %v", err)
+	}
+}
+
+func TestResolveNamed(t *testing.T) {
+	english := &englishGreeter{}
+	inventory := New().Add(english, WithName("english")).Add(&frenchGreeter{}, WithName("french"))
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := ResolveNamed[greeter](inventory, "english"); err != nil || got != greeter(english) {
+		t.Errorf("unexpected greeter: %v, %v", got, err)
+	}
+	var unresolved *UnresolvedError
+	if _, err := ResolveNamed[*englishGreeter](inventory, "french"); !errors.As(err, &unresolved) || unresolved.Name != "french" {
+		t.Errorf("the component of another type should not be resolved, got: %v", err)
+	}
+	if _, err := ResolveNamed[greeter](inventory, "german"); !errors.Is(err, ErrUnresolvedDependency) {
+		t.Errorf("the unknown name should not be resolved, got: %v", err)
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	english, french, british := &englishGreeter{}, &frenchGreeter{}, &englishGreeter{}
+	base := New().Add(english).Add(&providedDatabase{})
+	if err := base.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	child := base.Child().Add(french).Add(british, WithName("british"))
+	if err := child.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if greeters := ResolveAll[greeter](child); !reflect.DeepEqual(greeters, []greeter{english, french, british}) {
+		t.Errorf("the greeters should be in the order they were added: %v", greeters)
+	}
+	if components := ResolveAll[interface{}](child); len(components) != 4 {
+		t.Errorf("expected every component, got: %v", components)
+	}
+	if caches := ResolveAll[*lazyCache](child); caches != nil {
+		t.Errorf("expected no component, got: %v", caches)
+	}
+}
+
+func TestResolveNamedAmbiguity(t *testing.T) {
+	base := New().Add(&englishGreeter{}, WithName("greeter")).Add(&frenchGreeter{}, WithName("greeter"))
+	if err := base.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	var ambiguity *AmbiguityError
+	if _, err := ResolveNamed[greeter](base, "greeter"); !errors.As(err, &ambiguity) ||
+		!reflect.DeepEqual(ambiguity.Candidates, []string{"greeter", "greeter"}) {
+		t.Errorf("expected the name to be ambiguous, got: %v", err)
+	}
+
+	local := &frenchGreeter{}
+	child := base.Child().Add(local, WithName("greeter"))
+	if err := child.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ResolveNamed[greeter](child, "greeter"); err != nil || got != greeter(local) {
+		t.Errorf("the local container should shadow the parent ones: %v, %v", got, err)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Package secret resolves the references to secrets found in the configuration,
+such as file:///run/secrets/db_password or env://DB_PASS, through pluggable resolvers.
+
+The resolved values are tracked, so they can be masked from the logs and
+the configuration dumps with Redact.
+*/
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Mask replace the secrets in the redacted texts.
+const Mask = "******"
+
+const schemeSeparator = "://"
+
+var (
+	// ErrNotFound is returned if the referenced secret does not exist.
+	ErrNotFound = errors.New("secret not found")
+)
+
+// Resolver fetch the secret at the given location, the location is the reference
+// stripped of its scheme: /run/secrets/db_password for file:///run/secrets/db_password.
+type Resolver interface {
+	Resolve(location string) (string, error)
+}
+
+// ResolverFunc is an adapter to use ordinary functions as Resolver.
+type ResolverFunc func(location string) (string, error)
+
+// Resolve implements the Resolver interface.
+func (f ResolverFunc) Resolve(location string) (string, error) {
+	return f(location)
+}
+
+var registry = struct {
+	sync.RWMutex
+	resolvers map[string]Resolver
+	secrets   map[string]struct{}
+}{
+	resolvers: map[string]Resolver{
+		"file": ResolverFunc(fromFile),
+		"env":  ResolverFunc(fromEnv),
+	},
+	secrets: map[string]struct{}{},
+}
+
+func fromFile(location string) (string, error) {
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func fromEnv(location string) (string, error) {
+	value, ok := os.LookupEnv(location)
+	if !ok {
+		return "", fmt.Errorf("%w: environment variable %s", ErrNotFound, location)
+	}
+	return value, nil
+}
+
+// Register add or replace the Resolver used for the given scheme, such as "vault".
+func Register(scheme string, resolver Resolver) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.resolvers[scheme] = resolver
+}
+
+// Resolve return the secret referenced by the value and track it, the value is returned
+// as is if it does not start with the scheme of a registered Resolver.
+func Resolve(value string) (string, error) {
+	scheme, location, ok := strings.Cut(value, schemeSeparator)
+	if !ok {
+		return value, nil
+	}
+
+	registry.RLock()
+	resolver, ok := registry.resolvers[scheme]
+	registry.RUnlock()
+	if !ok {
+		return value, nil
+	}
+
+	resolved, err := resolver.Resolve(location)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve the %s secret: %w", scheme, err)
+	}
+	Track(resolved)
+	return resolved, nil
+}
+
+// Track register a value as a secret, so it is masked by Redact.
+func Track(value string) {
+	if value == "" {
+		return
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	registry.secrets[value] = struct{}{}
+}
+
+// IsSecret report if the value has been tracked as a secret.
+func IsSecret(value string) bool {
+	registry.RLock()
+	defer registry.RUnlock()
+	_, ok := registry.secrets[value]
+	return ok
+}
+
+// Redact replace every tracked secret found in the text by the Mask.
+// The overlapping secrets, such as a secret containing another one, are masked together
+// so no part of them is left, whatever the order they were tracked in.
+func Redact(text string) string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	var masked []bool
+	for value := range registry.secrets {
+		for offset := 0; offset < len(text); {
+			idx := strings.Index(text[offset:], value)
+			if idx < 0 {
+				break
+			}
+			if masked == nil {
+				masked = make([]bool, len(text))
+			}
+			start := offset + idx
+			for position := start; position < start+len(value); position++ {
+				masked[position] = true
+			}
+			offset = start + 1
+		}
+	}
+	if masked == nil {
+		return text
+	}
+
+	var redacted strings.Builder
+	for position := 0; position < len(text); position++ {
+		if !masked[position] {
+			redacted.WriteByte(text[position])
+			continue
+		}
+		if position == 0 || !masked[position-1] {
+			redacted.WriteString(Mask)
+		}
+	}
+	return redacted.String()
+}
@@ -0,0 +1,31 @@
+package secret
+
+import (
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	for _, value := range []string{"hunter", "hunter2pass", "2pa", "token-abc", "abc-def"} {
+		Track(value)
+	}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: "password=hunter2pass", expected: "password=" + Mask},
+		{text: "user=hunter, password=hunter2pass", expected: "user=" + Mask + ", password=" + Mask},
+		{text: "tokens=token-abc-def", expected: "tokens=" + Mask},
+		{text: "nothing to hide", expected: "nothing to hide"},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			// The secrets are kept in a map, the result must not depend on its order.
+			for run := 0; run < 50; run++ {
+				if redacted := Redact(test.text); redacted != test.expected {
+					t.Fatalf("expected %q, got: %q", test.expected, redacted)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package scaffolder
+
+import (
+	"encoding"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Vorian-Atreides/scaffolder/secret"
+)
+
+const secretTag = "secret"
+
+// keyTags are the tags naming the keys of the configuration files, in the same order
+// than the config package reads them, so a Snapshot can be loaded back.
+var keyTags = []string{"config", "json", "yaml", "toml", "flag"}
+
+// snapshotKey return the key of the field in the configuration files.
+func snapshotKey(field reflect.StructField) string {
+	for _, tag := range keyTags {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Snapshot return the effective configuration of every container, indexed by container name.
+//
+// The configuration of the components implementing the Configurable interface is read from
+// their Configuration, the exported fields of the others are used instead, skipping the dependencies.
+// The fields tagged with `secret:"true"` and the values tracked by the secret package are masked.
+// The fields are keyed the same way than the configuration files, after their config, json, yaml,
+// toml or flag tag, so the Snapshot can be written as a configuration file and loaded back.
+func (i *Inventory) Snapshot() map[string]interface{} {
+	snapshot := make(map[string]interface{}, len(i.containers))
+	for _, container := range i.containers {
+		if container.lazy {
+			continue
+		}
+		var target interface{} = container.value
+		if configurable, ok := container.value.(Configurable); ok {
+			target = configurable.Configuration()
+		}
+
+		value := reflect.ValueOf(target)
+		for value.Kind() == reflect.Ptr && !value.IsNil() {
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.Struct {
+			snapshot[container.name] = snapshotStruct(value)
+		}
+	}
+	return snapshot
+}
+
+// Dump write the Snapshot of the inventory as indented JSON.
+func (i *Inventory) Dump(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(i.Snapshot())
+}
+
+func snapshotStruct(structValue reflect.Value) map[string]interface{} {
+	fields := map[string]interface{}{}
+	structType := structValue.Type()
+	for y := 0; y < structType.NumField(); y++ {
+		fieldType := structType.Field(y)
+		fieldValue := structValue.Field(y)
+		if !fieldValue.CanSet() || !(isNested(fieldValue) || isConvertible(fieldValue.Type())) {
+			continue
+		}
+
+		key := snapshotKey(fieldType)
+		if isSecret, _ := strconv.ParseBool(fieldType.Tag.Get(secretTag)); isSecret && !fieldValue.IsZero() {
+			fields[key] = secret.Mask
+			continue
+		}
+		fields[key] = snapshotValue(fieldValue)
+	}
+	return fields
+}
+
+func snapshotValue(value reflect.Value) interface{} {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		return snapshotValue(value.Elem())
+	}
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return nil
+		}
+		return redact(string(text))
+	}
+
+	switch {
+	case value.Type() == durationType:
+		return value.Interface().(interface{ String() string }).String()
+	case isNested(value):
+		return snapshotStruct(value)
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return redact(value.String())
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil
+		}
+		items := make([]interface{}, value.Len())
+		for idx := range items {
+			items[idx] = snapshotValue(value.Index(idx))
+		}
+		return items
+	case reflect.Map:
+		if value.IsNil() {
+			return nil
+		}
+		m := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			m[formatValue(iter.Key())] = snapshotValue(iter.Value())
+		}
+		return m
+	}
+	return value.Interface()
+}
+
+func redact(value string) string {
+	return secret.Redact(value)
+}
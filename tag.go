@@ -0,0 +1,66 @@
+package scaffolder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	nameKey  = "name"
+	labelKey = "label"
+	lazyTag  = "lazy"
+)
+
+// tagOptions is the content of a scaffolder tag, the elements are separated by commas:
+//
+//   `scaffolder:"name=primary,optional,lazy,label=region:eu"`
+//
+// The name can also be given alone as the first element: `scaffolder:"primary,optional"`,
+// unless it is one of the flags, such as optional or lazy, which requires the name key.
+type tagOptions struct {
+	name     string
+	optional bool
+	lazy     bool
+	labels   map[string]string
+}
+
+func parseTag(tag string) (tagOptions, error) {
+	var options tagOptions
+	for idx, element := range strings.Split(tag, itemSeparator) {
+		key, value, hasValue := strings.Cut(element, "=")
+		switch {
+		case element == "":
+			continue
+		case key == nameKey && hasValue:
+			if options.name != "" {
+				return options, errors.New("the name is given twice")
+			}
+			if value == "" {
+				return options, errors.New("the name is empty")
+			}
+			options.name = value
+		case key == optionalTag && !hasValue:
+			options.optional = true
+		case key == lazyTag && !hasValue:
+			options.lazy = true
+		case key == labelKey && hasValue:
+			labelName, labelValue, ok := strings.Cut(value, keyValueSeparator)
+			if !ok || labelName == "" {
+				return options, fmt.Errorf("malformed label %q, expected label=key:value", value)
+			}
+			if _, ok := options.labels[labelName]; ok {
+				return options, fmt.Errorf("the label %q is given twice", labelName)
+			}
+			if options.labels == nil {
+				options.labels = make(map[string]string)
+			}
+			options.labels[labelName] = labelValue
+		case idx == 0 && !hasValue:
+			options.name = element
+		default:
+			return options, fmt.Errorf("unknown element %q", element)
+		}
+	}
+	return options, nil
+}
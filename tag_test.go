@@ -0,0 +1,134 @@
+package scaffolder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected tagOptions
+	}{
+		{tag: "", expected: tagOptions{}},
+		{tag: "primary", expected: tagOptions{name: "primary"}},
+		{tag: "primary,optional", expected: tagOptions{name: "primary", optional: true}},
+		{tag: ",optional", expected: tagOptions{optional: true}},
+		{tag: "optional", expected: tagOptions{optional: true}},
+		{tag: "name=optional", expected: tagOptions{name: "optional"}},
+		{
+			tag:      "name=primary,lazy,label=region:eu,label=tier:",
+			expected: tagOptions{name: "primary", lazy: true, labels: map[string]string{"region": "eu", "tier": ""}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.tag, func(t *testing.T) {
+			options, err := parseTag(test.tag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(options, test.expected) {
+				t.Errorf("expected %+v, got: %+v", test.expected, options)
+			}
+		})
+	}
+}
+
+func TestParseTagErrors(t *testing.T) {
+	for _, tag := range []string{
+		"name=",
+		"primary,name=secondary",
+		"label=region",
+		"label=:eu",
+		"label=region:eu,label=region:us",
+		"lazy=true",
+		"primary,unknown",
+	} {
+		t.Run(tag, func(t *testing.T) {
+			if options, err := parseTag(tag); err == nil {
+				t.Errorf("expected the tag to be rejected, got: %+v", options)
+			}
+		})
+	}
+}
+
+type malformedTags struct {
+	Empty   *englishGreeter `scaffolder:"name=,optional"`
+	NotFunc *englishGreeter `scaffolder:"english,lazy"`
+	Valid   *englishGreeter
+}
+
+func TestInventoryTagErrors(t *testing.T) {
+	err := New().Add(&englishGreeter{}).Add(&malformedTags{}, WithName("malformed")).Compile()
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected every malformed tag to be reported, got: %v", err)
+	}
+	for idx, field := range []string{"Empty", "NotFunc"} {
+		var tagErr *TagError
+		if !errors.As(errs[idx], &tagErr) || !errors.Is(errs[idx], ErrInvalidTag) {
+			t.Fatalf("unexpected error #%d: %v", idx, errs[idx])
+		}
+		if tagErr.Container != "malformed" || tagErr.Field != field {
+			t.Errorf("unexpected tag error #%d: %+v", idx, tagErr)
+		}
+	}
+}
+
+type labelledDatabases struct {
+	Europe    *providedDatabase   `scaffolder:"label=region:eu"`
+	Primary   *providedDatabase   `scaffolder:"name=primary,label=region:us,optional"`
+	Databases []*providedDatabase `scaffolder:"label=region:eu"`
+}
+
+func TestInventoryLabels(t *testing.T) {
+	europe, america := &providedDatabase{dsn: "eu"}, &providedDatabase{dsn: "us"}
+	databases := &labelledDatabases{}
+	inventory := New().
+		Add(europe, WithName("europe"), WithLabel("region", "eu")).
+		Add(america, WithName("america"), WithLabel("region", "us")).
+		Add(databases)
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if databases.Europe != europe {
+		t.Errorf("the labelled database should be assigned: %+v", databases.Europe)
+	}
+	if databases.Primary != america {
+		t.Errorf("the labels should still select the database without the name: %+v", databases.Primary)
+	}
+	if !reflect.DeepEqual(databases.Databases, []*providedDatabase{europe}) {
+		t.Errorf("the collection should be filtered by the labels: %v", databases.Databases)
+	}
+}
+
+// lazyService depends on its caller, the lazy field does not create a cycle.
+type lazyService struct {
+	Caller func() *lazyCaller `scaffolder:",lazy"`
+}
+
+type lazyCaller struct {
+	Service *lazyService
+}
+
+func TestInventoryLazyFields(t *testing.T) {
+	service, caller := &lazyService{}, &lazyCaller{}
+	inventory := New().Add(service).Add(caller)
+	if err := inventory.Compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if caller.Service != service || service.Caller() != caller {
+		t.Errorf("the components should be assigned: %+v, %+v", caller, service)
+	}
+	order, err := inventory.Graph().Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := containerNamesOf(order); !reflect.DeepEqual(names, []string{"lazyService", "lazyCaller"}) {
+		t.Errorf("the lazy field should not constrain the order: %v", names)
+	}
+}
@@ -0,0 +1,165 @@
+package scaffolder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	validateTag   = "validate"
+	ruleSeparator = ","
+)
+
+var (
+	// ErrInvalidRule is returned if a validate tag is malformed or does not apply to its field.
+	ErrInvalidRule = errors.New("invalid validation rule")
+	// ErrRequired is returned if a required field holds its zero value.
+	ErrRequired = errors.New("the field is required")
+	// ErrOutOfRange is returned if a field does not respect its min or max rule.
+	ErrOutOfRange = errors.New("the field is out of range")
+	// ErrNotOneOf is returned if a field does not hold one of the values of its oneof rule.
+	ErrNotOneOf = errors.New("the field does not hold one of the allowed values")
+)
+
+// FieldError describe the rule that a field does not respect.
+type FieldError struct {
+	Path string
+	Rule string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Err)
+}
+
+// Unwrap return the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validate check the exported fields of the structure against the rules of their validate tag,
+// every violation is reported as a FieldError and aggregated into Errors.
+//
+//   type Config struct {
+//   	Port    int           `validate:"required,min=1,max=65535"`
+//   	Level   string        `validate:"oneof=debug info"`
+//   	Timeout time.Duration `validate:"min=1s"`
+//   	Hosts   []string      `validate:"min=1"`
+//   }
+//
+// The min and max rules compare the value of the numbers and durations
+// and the length of the strings, slices and maps.
+func Validate(target interface{}) error {
+	value := reflect.ValueOf(target)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs Errors
+	validateStruct(value, value.Type().Name(), &errs)
+	return errs.ErrorOrNil()
+}
+
+func validateStruct(structValue reflect.Value, path string, errs *Errors) {
+	structType := structValue.Type()
+	for y := 0; y < structType.NumField(); y++ {
+		fieldType := structType.Field(y)
+		fieldValue := structValue.Field(y)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		fieldPath := path + "." + fieldType.Name
+		for _, rule := range strings.Split(fieldType.Tag.Get(validateTag), ruleSeparator) {
+			if rule = strings.TrimSpace(rule); rule == "" {
+				continue
+			}
+			if err := validateRule(fieldValue, rule); err != nil {
+				*errs = append(*errs, &FieldError{Path: fieldPath, Rule: rule, Err: err})
+			}
+		}
+		if isNested(fieldValue) {
+			validateStruct(fieldValue, fieldPath, errs)
+		}
+	}
+}
+
+func validateRule(value reflect.Value, rule string) error {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if value.IsZero() {
+			return ErrRequired
+		}
+	case "min":
+		return validateBound(value, arg, func(cmp int) bool { return cmp >= 0 }, "at least")
+	case "max":
+		return validateBound(value, arg, func(cmp int) bool { return cmp <= 0 }, "at most")
+	case "oneof":
+		allowed := strings.Fields(arg)
+		actual := formatValue(value)
+		for _, candidate := range allowed {
+			if candidate == actual {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %q is not one of %s", ErrNotOneOf, actual, strings.Join(allowed, ", "))
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidRule, rule)
+	}
+	return nil
+}
+
+// validateBound compare the value with the bound and check the result of the comparison.
+func validateBound(value reflect.Value, arg string, check func(cmp int) bool, explanation string) error {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		bound, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidRule, err)
+		}
+		if !check(compare(float64(value.Len()), float64(bound))) {
+			return fmt.Errorf("%w: the length must be %s %d", ErrOutOfRange, explanation, bound)
+		}
+		return nil
+	}
+
+	// The bound is converted into the type of the value, so the durations
+	// can be written as such: min=1s.
+	bound := reflect.New(value.Type()).Elem()
+	if err := SetString(bound, arg); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRule, err)
+	}
+
+	var cmp int
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		cmp = compare(float64(value.Int()), float64(bound.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		cmp = compare(float64(value.Uint()), float64(bound.Uint()))
+	case reflect.Float32, reflect.Float64:
+		cmp = compare(value.Float(), bound.Float())
+	default:
+		return fmt.Errorf("%w: %s cannot be compared", ErrInvalidRule, value.Type())
+	}
+	if !check(cmp) {
+		return fmt.Errorf("%w: must be %s %s", ErrOutOfRange, explanation, arg)
+	}
+	return nil
+}
+
+func compare(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}